@@ -1,10 +1,19 @@
 package tencentcloud
 
 import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/url"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	sdkErrors "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/errors"
+	tag "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/tag/v20180813"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -210,6 +219,89 @@ func TestIsExpectError(t *testing.T) {
 	assert.Equalf(t, isExpectError(err, unExpectedShort), false, "")
 }
 
+func TestBuildTagResourceName(t *testing.T) {
+	// the `resource_name` attribute of taggable resources relies on these formats
+	assert.Equalf(t,
+		"qcs::cfs:ap-guangzhou:uin/:snap/snap-12345678",
+		BuildTagResourceName("cfs", "snap", "ap-guangzhou", "snap-12345678"), "")
+	assert.Equalf(t,
+		"qcs::vpc:ap-guangzhou:uin/:eip/eip-12345678",
+		BuildTagResourceName("vpc", "eip", "ap-guangzhou", "eip-12345678"), "")
+	assert.Equalf(t,
+		"qcs::vpc:ap-guangzhou:uin/:vpngw/vpngw-12345678",
+		BuildTagResourceName("vpc", "vpngw", "ap-guangzhou", "vpngw-12345678"), "")
+	assert.Equalf(t,
+		"qcs::cos:ap-guangzhou:uid/:bucket/bucket-12345678",
+		BuildTagResourceName("cos", "bucket", "ap-guangzhou", "bucket-12345678"), "")
+}
+
+func TestBuildTagResourceNameWithUin(t *testing.T) {
+	// tag operations scoped by account must carry the resolved uin
+	assert.Equalf(t,
+		"qcs::cfs:ap-guangzhou:uin/100012345678:snap/snap-12345678",
+		BuildTagResourceNameWithUin("cfs", "snap", "ap-guangzhou", "snap-12345678", "100012345678"), "")
+	assert.Equalf(t,
+		"qcs::cos:ap-guangzhou:uid/100012345678:bucket/bucket-12345678",
+		BuildTagResourceNameWithUin("cos", "bucket", "ap-guangzhou", "bucket-12345678", "100012345678"), "")
+}
+
+func TestMergeResourceTagsPage(t *testing.T) {
+	// a resource carrying more than DESCRIBE_TAGS_LIMIT tags is returned in
+	// several pages; every page must be folded into the same map
+	page1 := []*tag.TagResource{
+		{ResourceId: helper.String("eip-12345678"), TagKey: helper.String("team"), TagValue: helper.String("infra")},
+		{ResourceId: helper.String("eip-87654321"), TagKey: helper.String("team"), TagValue: helper.String("other")},
+	}
+	page2 := []*tag.TagResource{
+		{ResourceId: helper.String("eip-12345678"), TagKey: helper.String("env"), TagValue: helper.String("prod")},
+	}
+
+	tags := mergeResourceTagsPage(nil, page1, "eip-12345678")
+	tags = mergeResourceTagsPage(tags, page2, "eip-12345678")
+
+	assert.Equalf(t, map[string]string{"team": "infra", "env": "prod"}, tags, "")
+
+	// no page mentions the resource at all
+	assert.Nil(t, mergeResourceTagsPage(nil, page2, "eip-00000000"))
+}
+
+func TestMergeResourceTagsPages(t *testing.T) {
+	// a batched read groups tags per resource and folds later pages into the
+	// maps started by earlier ones
+	page1 := []*tag.TagResource{
+		{ResourceId: helper.String("ppm-12345678"), TagKey: helper.String("team"), TagValue: helper.String("infra")},
+		{ResourceId: helper.String("ppm-87654321"), TagKey: helper.String("team"), TagValue: helper.String("other")},
+	}
+	page2 := []*tag.TagResource{
+		{ResourceId: helper.String("ppm-12345678"), TagKey: helper.String("env"), TagValue: helper.String("prod")},
+	}
+
+	byResource := make(map[string]map[string]string)
+	mergeResourceTagsPages(byResource, page1)
+	mergeResourceTagsPages(byResource, page2)
+
+	assert.Equalf(t, map[string]string{"team": "infra", "env": "prod"}, byResource["ppm-12345678"], "")
+	assert.Equalf(t, map[string]string{"team": "other"}, byResource["ppm-87654321"], "")
+
+	// an untagged resource gets no entry
+	_, ok := byResource["ppm-00000000"]
+	assert.Equalf(t, false, ok, "")
+}
+
+func TestRetryErrorQuotaExhausted(t *testing.T) {
+	// a quota error must fail immediately instead of being retried until timeout
+	err := sdkErrors.NewTencentCloudSDKError("LimitExceeded", "vpn gateway count exceeds quota", "")
+	retryErr := retryError(err)
+	assert.Equalf(t, retryErr.Retryable, false, "")
+	assert.Containsf(t, retryErr.Err.Error(), "quota increase", "")
+
+	// sub codes of the LimitExceeded family are classified the same way
+	err = sdkErrors.NewTencentCloudSDKError("LimitExceeded.VpnGwLimitExceeded", "", "")
+	retryErr = retryError(err)
+	assert.Equalf(t, retryErr.Retryable, false, "")
+	assert.Containsf(t, retryErr.Err.Error(), "quota increase", "")
+}
+
 func TestYamlParser(t *testing.T) {
 	yamlStr := `test`
 	yaml, err := YamlParser(yamlStr)
@@ -224,3 +316,227 @@ desc: this is a description`
 	assert.Equalf(t, reflect.TypeOf(yaml1).String(), "map[interface {}]interface {}", "")
 	assert.Equalf(t, yaml1["name"], "test-name", "")
 }
+
+func TestRedactRequestBody(t *testing.T) {
+	body := `{"ConfigName":"my-config","ConfigValue":"db_password=hunter2","Nested":{"Cert":"-----BEGIN CERTIFICATE-----"}}`
+	masked := redactRequestBody(body)
+
+	assert.NotContainsf(t, masked, "hunter2", "")
+	assert.NotContainsf(t, masked, "BEGIN CERTIFICATE", "")
+	assert.Containsf(t, masked, `"ConfigValue":"******"`, "")
+	assert.Containsf(t, masked, `"Cert":"******"`, "")
+	// non sensitive fields are kept untouched
+	assert.Containsf(t, masked, `"ConfigName":"my-config"`, "")
+
+	// a body that is not a JSON object is returned as is
+	assert.Equalf(t, redactRequestBody("not-json"), "not-json", "")
+}
+
+func TestWriteToFileWithIndent(t *testing.T) {
+	data := map[string]interface{}{"name": "test", "protocols": []string{"udp:all"}}
+
+	prettyFile := filepath.Join(t.TempDir(), "pretty.json")
+	assert.Equalf(t, writeToFileWithIndent(prettyFile, data, "\t"), nil, "")
+	pretty, err := ioutil.ReadFile(prettyFile)
+	assert.Equalf(t, err, nil, "")
+	assert.Containsf(t, string(pretty), "\n\t\"name\"", "")
+
+	compactFile := filepath.Join(t.TempDir(), "compact.json")
+	assert.Equalf(t, writeToFileWithIndent(compactFile, data, ""), nil, "")
+	compact, err := ioutil.ReadFile(compactFile)
+	assert.Equalf(t, err, nil, "")
+	assert.NotContainsf(t, string(compact), "\n", "")
+	assert.Containsf(t, string(compact), `"name":"test"`, "")
+}
+
+func TestSplitModifyTagsBatches(t *testing.T) {
+	// 60 replace operations plus 5 deletions must be issued as multiple calls
+	replaceTags := make(map[string]string, 60)
+	for i := 0; i < 60; i++ {
+		replaceTags[fmt.Sprintf("key-%d", i)] = "value"
+	}
+	deleteKeys := []string{"d-0", "d-1", "d-2", "d-3", "d-4"}
+
+	batches := splitModifyTagsBatches(replaceTags, deleteKeys, MODIFY_TAGS_LIMIT)
+	assert.Equalf(t, len(batches), 7, "")
+
+	totalReplace, totalDelete := 0, 0
+	for _, batch := range batches {
+		assert.LessOrEqualf(t, len(batch.replaceTags)+len(batch.deleteTags), MODIFY_TAGS_LIMIT, "")
+		totalReplace += len(batch.replaceTags)
+		totalDelete += len(batch.deleteTags)
+	}
+	assert.Equalf(t, totalReplace, 60, "")
+	assert.Equalf(t, totalDelete, 5, "")
+
+	// nothing to do produces no calls
+	assert.Equalf(t, len(splitModifyTagsBatches(nil, nil, MODIFY_TAGS_LIMIT)), 0, "")
+}
+
+func TestNormalizeResourceTagsCase(t *testing.T) {
+	configured := map[string]string{"env": "prod", "Team": "DB"}
+
+	// keys and values differing only by case fold back to the configured form
+	normalized := normalizeResourceTagsCase(map[string]string{"Env": "PROD", "team": "db"}, configured)
+	assert.Equal(t, configured, normalized)
+
+	// a real value change must stay visible as a diff
+	normalized = normalizeResourceTagsCase(map[string]string{"env": "staging"}, configured)
+	assert.Equal(t, map[string]string{"env": "staging"}, normalized)
+
+	// tags without a configured counterpart pass through untouched
+	normalized = normalizeResourceTagsCase(map[string]string{"Extra": "Value"}, configured)
+	assert.Equal(t, map[string]string{"Extra": "Value"}, normalized)
+
+	// nothing configured means nothing to normalize against
+	assert.Nil(t, normalizeResourceTagsCase(nil, configured))
+	input := map[string]string{"Env": "PROD"}
+	assert.Equal(t, input, normalizeResourceTagsCase(input, nil))
+}
+
+func TestTagReadCache(t *testing.T) {
+	cache := &tagReadCache{ttl: time.Minute}
+	now := time.Now()
+	calls := 0
+	describe := func() (map[string]string, error) {
+		calls++
+		return map[string]string{"env": "prod"}, nil
+	}
+
+	// two same-scope reads within the window hit the API once
+	first, err := cache.fetch("vpc#eip#ap-guangzhou#eip-1", now, describe)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := cache.fetch("vpc#eip#ap-guangzhou#eip-1", now.Add(time.Second), describe)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected one underlying API call for two same-scope reads, got %d", calls)
+	}
+	if second["env"] != "prod" {
+		t.Errorf("expected cached tags, got %v", second)
+	}
+
+	// a caller mutating its result must not poison the cache
+	first["env"] = "dev"
+	if third, _ := cache.fetch("vpc#eip#ap-guangzhou#eip-1", now, describe); third["env"] != "prod" {
+		t.Errorf("expected the cache to be unaffected by caller mutation, got %v", third)
+	}
+
+	// a different scope misses the cache
+	if _, err := cache.fetch("vpc#eip#ap-guangzhou#eip-2", now, describe); err != nil || calls != 2 {
+		t.Errorf("expected a fresh fetch for another resource, got %d calls, err %v", calls, err)
+	}
+
+	// an expired entry is fetched again
+	if _, err := cache.fetch("vpc#eip#ap-guangzhou#eip-1", now.Add(2*time.Minute), describe); err != nil || calls != 3 {
+		t.Errorf("expected a fresh fetch after the ttl, got %d calls, err %v", calls, err)
+	}
+
+	// a modification drops everything so the next read is fresh
+	cache.reset()
+	if _, err := cache.fetch("vpc#eip#ap-guangzhou#eip-2", now, describe); err != nil || calls != 4 {
+		t.Errorf("expected a fresh fetch after reset, got %d calls, err %v", calls, err)
+	}
+
+	// errors are surfaced and not cached
+	if _, err := cache.fetch("vpc#eip#ap-guangzhou#eip-3", now, func() (map[string]string, error) {
+		return nil, fmt.Errorf("describe failed")
+	}); err == nil {
+		t.Error("expected the describe error to be surfaced")
+	}
+	if _, err := cache.fetch("vpc#eip#ap-guangzhou#eip-3", now, describe); err != nil || calls != 5 {
+		t.Errorf("expected a failed fetch not to be cached, got %d calls, err %v", calls, err)
+	}
+}
+
+func TestBackoffPollInterval(t *testing.T) {
+	base := time.Second
+	max := 30 * time.Second
+
+	// the interval doubles with every attempt until it reaches the cap
+	previous := time.Duration(0)
+	for attempt := 0; attempt < 4; attempt++ {
+		interval := backoffPollInterval(attempt, base, max)
+		if interval <= previous {
+			t.Errorf("attempt %d: expected a growing interval, got %v after %v", attempt, interval, previous)
+		}
+		previous = interval
+	}
+
+	if interval := backoffPollInterval(10, base, max); interval != max {
+		t.Errorf("expected the interval to cap at %v, got %v", max, interval)
+	}
+}
+
+func TestBuildStateChangeConfWithBackoff(t *testing.T) {
+	conf := BuildStateChangeConfWithBackoff([]string{"creating"}, []string{"available"}, time.Minute, time.Second,
+		func() (interface{}, string, error) {
+			return struct{}{}, "creating", nil
+		})
+
+	// every refresh backs the poll interval further off
+	previous := time.Duration(0)
+	for i := 0; i < 4; i++ {
+		if _, _, err := conf.Refresh(); err != nil {
+			t.Fatalf("refresh failed: %v", err)
+		}
+		if conf.PollInterval <= previous {
+			t.Errorf("refresh %d: expected a growing poll interval, got %v after %v", i, conf.PollInterval, previous)
+		}
+		previous = conf.PollInterval
+	}
+}
+
+func TestWriteToFileCreatesParentDirs(t *testing.T) {
+	// a result_output_file inside a directory that does not exist yet must
+	// not fail with an obscure stat/write error
+	path := filepath.Join(t.TempDir(), "nested", "deeper", "result.json")
+
+	if err := writeToFile(path, map[string]interface{}{"key": "value"}); err != nil {
+		t.Fatalf("write to nested path failed: %v", err)
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read back failed: %v", err)
+	}
+	if !strings.Contains(string(content), "value") {
+		t.Errorf("unexpected file content: %s", content)
+	}
+}
+
+type fakeTimeoutError struct{ timeout bool }
+
+func (e *fakeTimeoutError) Error() string   { return "dial tcp: i/o timeout" }
+func (e *fakeTimeoutError) Timeout() bool   { return e.timeout }
+func (e *fakeTimeoutError) Temporary() bool { return false }
+
+func TestRetryErrorNetworkTimeouts(t *testing.T) {
+	// a simulated connection timeout is retried
+	if retryErr := retryError(&fakeTimeoutError{timeout: true}); !retryErr.Retryable {
+		t.Errorf("expected a net.Error timeout to be retryable")
+	}
+	// the same error type without the timeout flag still fails hard
+	if retryErr := retryError(&fakeTimeoutError{}); retryErr.Retryable {
+		t.Errorf("expected a non-timeout net.Error to stay non-retryable")
+	}
+
+	// temporary or timed-out DNS lookups are retried, even wrapped the way
+	// the http client reports them
+	dnsErr := &net.DNSError{Err: "server misbehaving", Name: "cvm.tencentcloudapi.com", IsTemporary: true}
+	wrapped := &url.Error{Op: "Post", URL: "https://cvm.tencentcloudapi.com", Err: dnsErr}
+	if retryErr := retryError(wrapped); !retryErr.Retryable {
+		t.Errorf("expected a temporary DNS error to be retryable")
+	}
+	if retryErr := retryError(&net.DNSError{Err: "no such host", Name: "cvm.tencentcloudapi.com"}); retryErr.Retryable {
+		t.Errorf("expected a hard DNS failure to stay non-retryable")
+	}
+
+	// unrelated errors keep failing fast
+	if retryErr := retryError(fmt.Errorf("bad request")); retryErr.Retryable {
+		t.Errorf("expected a plain error to stay non-retryable")
+	}
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	goerrors "errors"
 	"fmt"
 	"io/ioutil"
 	"log"
+	"net"
 	"os"
+	"path/filepath"
 	"os/user"
 	"reflect"
 	"strconv"
@@ -20,6 +23,7 @@ import (
 	"github.com/mitchellh/go-homedir"
 	"github.com/pkg/errors"
 	sdkErrors "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/errors"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
 	"github.com/tencentyun/cos-go-sdk-v5"
 	"gopkg.in/yaml.v2"
 )
@@ -67,21 +71,15 @@ var needProtect = getEnvDefault(SWEEPER_NEED_PROTECT, 0)
 // because when some product return this error, retry won't fix anything.
 const InternalError = "InternalError"
 
-// retryableErrorCode is retryable error code
-var retryableErrorCode = []string{
-	// client
-	"ClientError.NetworkError",
-	"ClientError.HttpStatusCodeError",
-	// common
-	"FailedOperation",
-	"TradeUnknownError",
-	"RequestLimitExceeded",
-	"ResourceInUse",
-	"ResourceUnavailable",
-	// cbs
-	"ResourceBusy",
-	// teo
-	"InvalidParameter.ActionInProgress",
+// retryableErrorCode is retryable error code, shared with helper.RetryDescribe
+// so reads going through the wrapper classify errors the same way.
+var retryableErrorCode = helper.RetryableErrorCodes
+
+// quotaLimitErrorCode is the `LimitExceeded` error family returned when a resource
+// count exceeds the account quota. Retrying can never succeed, so these errors
+// fail fast with a hint to request a quota increase.
+var quotaLimitErrorCode = []string{
+	"LimitExceeded",
 }
 
 // retryableCosErrorCode is retryable error code for COS/CI SDK
@@ -147,6 +145,54 @@ func logElapsed(mark ...string) func() {
 	}
 }
 
+// sensitiveLogFields lists request/response field names whose values must not
+// appear in DEBUG request dumps.
+var sensitiveLogFields = map[string]bool{
+	"ConfigValue":           true,
+	"Cert":                  true,
+	"Key":                   true,
+	"CertificateContent":    true,
+	"CertificatePrivateKey": true,
+	"Password":              true,
+}
+
+const sensitiveLogMask = "******"
+
+// redactRequestBody masks the values of known-sensitive fields in a JSON
+// request/response body before it is written to the log. The body is returned
+// unchanged if it is not a JSON object.
+func redactRequestBody(body string) string {
+	var decoded map[string]interface{}
+	if err := json.Unmarshal([]byte(body), &decoded); err != nil {
+		return body
+	}
+	redactSensitiveFields(decoded)
+	masked, err := json.Marshal(decoded)
+	if err != nil {
+		return body
+	}
+	return string(masked)
+}
+
+func redactSensitiveFields(object map[string]interface{}) {
+	for k, v := range object {
+		if sensitiveLogFields[k] && v != nil {
+			object[k] = sensitiveLogMask
+			continue
+		}
+		switch value := v.(type) {
+		case map[string]interface{}:
+			redactSensitiveFields(value)
+		case []interface{}:
+			for _, item := range value {
+				if nested, ok := item.(map[string]interface{}); ok {
+					redactSensitiveFields(nested)
+				}
+			}
+		}
+	}
+}
+
 // for Provider produced inconsistent result after apply
 func inconsistentCheck(d *schema.ResourceData, meta interface{}) func() {
 	oldJson, _ := json.Marshal(d.State())
@@ -158,10 +204,30 @@ func inconsistentCheck(d *schema.ResourceData, meta interface{}) func() {
 	}
 }
 
+// isNetworkRetryableError reports whether err is a transient network blip, a
+// net.Error timeout or a temporary DNS resolution failure, that is worth
+// retrying within the existing retry budget instead of aborting the apply.
+func isNetworkRetryableError(err error) bool {
+	var dnsErr *net.DNSError
+	if goerrors.As(err, &dnsErr) {
+		return dnsErr.IsTimeout || dnsErr.IsTemporary
+	}
+	var netErr net.Error
+	if goerrors.As(err, &netErr) {
+		return netErr.Timeout()
+	}
+	return false
+}
+
 // retryError returns retry error
 func retryError(err error, additionRetryableError ...string) *resource.RetryError {
 	switch realErr := errors.Cause(err).(type) {
 	case *sdkErrors.TencentCloudSDKError:
+		if isExpectError(realErr, quotaLimitErrorCode) {
+			log.Printf("[CRITAL] NonRetryable quota error: %v", err)
+			return resource.NonRetryableError(fmt.Errorf("%v, the account quota of this resource is exhausted, please request a quota increase", err))
+		}
+
 		if isExpectError(realErr, retryableErrorCode) {
 			log.Printf("[CRITAL] Retryable defined error: %v", err)
 			return resource.RetryableError(err)
@@ -185,6 +251,10 @@ func retryError(err error, additionRetryableError ...string) *resource.RetryErro
 			}
 		}
 	default:
+		if isNetworkRetryableError(realErr) {
+			log.Printf("[CRITAL] Retryable network error: %v", err)
+			return resource.RetryableError(err)
+		}
 	}
 
 	log.Printf("[CRITAL] NonRetryable error: %v", err)
@@ -283,6 +353,12 @@ func IsString(data interface{}) bool {
 
 // writeToFile write data to file
 func writeToFile(filePath string, data interface{}) error {
+	return writeToFileWithIndent(filePath, data, "\t")
+}
+
+// writeToFileWithIndent serializes data to filePath; an empty indent writes
+// compact JSON
+func writeToFileWithIndent(filePath string, data interface{}, indent string) error {
 	if strings.HasPrefix(filePath, "~") {
 		usr, err := user.Current()
 		if err != nil {
@@ -293,6 +369,12 @@ func writeToFile(filePath string, data interface{}) error {
 		}
 	}
 
+	if dir := filepath.Dir(filePath); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("create result output dir error,reason %s", err.Error())
+		}
+	}
+
 	fileInfo, err := os.Stat(filePath)
 	if err != nil && !os.IsNotExist(err) {
 		return fmt.Errorf("stat old file error,reason %s", err.Error())
@@ -311,7 +393,12 @@ func writeToFile(filePath string, data interface{}) error {
 		return ioutil.WriteFile(filePath, []byte(data.(string)), 0422)
 	}
 
-	jsonStr, err := json.MarshalIndent(data, "", "\t")
+	var jsonStr []byte
+	if indent == "" {
+		jsonStr, err = json.Marshal(data)
+	} else {
+		jsonStr, err = json.MarshalIndent(data, "", indent)
+	}
 	if err != nil {
 		return fmt.Errorf("json decode error,reason %s", err.Error())
 	}
@@ -368,6 +455,19 @@ func BuildTagResourceName(serviceType, resourceType, region, id string) string {
 	}
 }
 
+// BuildTagResourceNameWithUin works like BuildTagResourceName but fills in the
+// account uin segment, which some products require to scope tag operations to
+// the right account.
+func BuildTagResourceNameWithUin(serviceType, resourceType, region, id, uin string) string {
+	switch serviceType {
+	case "cos":
+		return fmt.Sprintf("qcs::%s:%s:uid/%s:%s/%s", serviceType, region, uin, resourceType, id)
+
+	default:
+		return fmt.Sprintf("qcs::%s:%s:uin/%s:%s/%s", serviceType, region, uin, resourceType, id)
+	}
+}
+
 // IsContains returns whether value is within array
 func IsContains(array interface{}, value interface{}) bool {
 	vv := reflect.ValueOf(array)
@@ -524,6 +624,56 @@ func Base64ToString(config string) (string, error) {
 	return string(strConfig), nil
 }
 
+// backoffPollInterval doubles the poll interval with every attempt, starting
+// from base and capped at max, so long-running waiters put less pressure on
+// rate-limited APIs.
+func backoffPollInterval(attempt int, base, max time.Duration) time.Duration {
+	interval := base
+	for i := 0; i < attempt && interval < max; i++ {
+		interval *= 2
+	}
+	if interval > max {
+		interval = max
+	}
+	return interval
+}
+
+// BuildStateChangeConfWithBackoff behaves like BuildStateChangeConf but grows
+// the poll interval with every refresh instead of polling at a fixed delay.
+func BuildStateChangeConfWithBackoff(pending, target []string, timeout, delay time.Duration, refresh resource.StateRefreshFunc) *resource.StateChangeConf {
+	conf := BuildStateChangeConf(pending, target, timeout, delay, nil)
+	attempt := 0
+	conf.Refresh = func() (interface{}, string, error) {
+		// WaitForState reads PollInterval after each refresh, so the next
+		// sleep picks up the backed-off value
+		conf.PollInterval = backoffPollInterval(attempt, delay, 30*time.Second)
+		attempt++
+		return refresh()
+	}
+	return conf
+}
+
+// checkChargeTypeOperation validates an operation that is only permitted for
+// resources of a particular charge type, e.g. renewal of a prepaid instance.
+// Resources share it so the error message stays consistent across services.
+func checkChargeTypeOperation(operation, chargeType, requiredChargeType string) error {
+	if chargeType != requiredChargeType {
+		return fmt.Errorf("invalid %s, only supported when charge type is `%s`, current charge type is `%s`",
+			operation, requiredChargeType, chargeType)
+	}
+	return nil
+}
+
+// checkChargeTypeSwitch validates a charge type change; the cloud APIs only
+// support switching from prepaid to postpaid, never the other direction.
+func checkChargeTypeSwitch(oldChargeType, newChargeType, prepaid, postpaid string) error {
+	if oldChargeType == postpaid && newChargeType == prepaid {
+		return fmt.Errorf("invalid charge type change from `%s` to `%s`, only the `%s` to `%s` direction is supported",
+			oldChargeType, newChargeType, prepaid, postpaid)
+	}
+	return nil
+}
+
 func BuildStateChangeConf(pending, target []string, timeout, delay time.Duration, refresh resource.StateRefreshFunc) *resource.StateChangeConf {
 	return &resource.StateChangeConf{
 		Pending:    pending,
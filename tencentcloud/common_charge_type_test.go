@@ -0,0 +1,43 @@
+package tencentcloud
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckChargeTypeOperation(t *testing.T) {
+	if err := checkChargeTypeOperation("prepaid_period change", VPN_CHARGE_TYPE_PREPAID, VPN_CHARGE_TYPE_PREPAID); err != nil {
+		t.Errorf("expected a prepaid gateway to permit renewal, got %v", err)
+	}
+
+	err := checkChargeTypeOperation("prepaid_period change", VPN_CHARGE_TYPE_POSTPAID_BY_HOUR, VPN_CHARGE_TYPE_PREPAID)
+	if err == nil {
+		t.Fatal("expected a postpaid gateway to reject renewal")
+	}
+	if !strings.Contains(err.Error(), VPN_CHARGE_TYPE_PREPAID) || !strings.Contains(err.Error(), VPN_CHARGE_TYPE_POSTPAID_BY_HOUR) {
+		t.Errorf("expected the error to name both charge types, got %v", err)
+	}
+}
+
+func TestCheckChargeTypeSwitch(t *testing.T) {
+	cases := []struct {
+		oldChargeType string
+		newChargeType string
+		permitted     bool
+	}{
+		{VPN_CHARGE_TYPE_PREPAID, VPN_CHARGE_TYPE_POSTPAID_BY_HOUR, true},
+		{VPN_CHARGE_TYPE_POSTPAID_BY_HOUR, VPN_CHARGE_TYPE_PREPAID, false},
+		// no-op "changes" are left for the API to ignore
+		{VPN_CHARGE_TYPE_PREPAID, VPN_CHARGE_TYPE_PREPAID, true},
+		{VPN_CHARGE_TYPE_POSTPAID_BY_HOUR, VPN_CHARGE_TYPE_POSTPAID_BY_HOUR, true},
+	}
+	for _, c := range cases {
+		err := checkChargeTypeSwitch(c.oldChargeType, c.newChargeType, VPN_CHARGE_TYPE_PREPAID, VPN_CHARGE_TYPE_POSTPAID_BY_HOUR)
+		if c.permitted && err != nil {
+			t.Errorf("switch %s -> %s: expected permitted, got %v", c.oldChargeType, c.newChargeType, err)
+		}
+		if !c.permitted && err == nil {
+			t.Errorf("switch %s -> %s: expected rejected", c.oldChargeType, c.newChargeType)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+/*
+Use this data source to query detailed information of a single cfs snapshot by id.
+
+Example Usage
+
+```hcl
+data "tencentcloud_cfs_snapshot" "snapshot" {
+  snapshot_id = "snap-dffw2kfh"
+}
+```
+*/
+package tencentcloud
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	cfs "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cfs/v20190719"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
+)
+
+func dataSourceTencentCloudCfsSnapshot() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceTencentCloudCfsSnapshotRead,
+		Schema: map[string]*schema.Schema{
+			"snapshot_id": {
+				Required:    true,
+				Type:        schema.TypeString,
+				Description: "ID of the snapshot to look up.",
+			},
+
+			// Computed values
+			"file_system_id": {
+				Computed:    true,
+				Type:        schema.TypeString,
+				Description: "ID of the file system the snapshot was taken from.",
+			},
+			"snapshot_name": {
+				Computed:    true,
+				Type:        schema.TypeString,
+				Description: "Name of the snapshot.",
+			},
+			"status": {
+				Computed:    true,
+				Type:        schema.TypeString,
+				Description: "Status of the snapshot.",
+			},
+			"size": {
+				Computed:    true,
+				Type:        schema.TypeInt,
+				Description: "Size of the snapshot in MiB.",
+			},
+			"create_time": {
+				Computed:    true,
+				Type:        schema.TypeString,
+				Description: "Creation time of the snapshot.",
+			},
+			"tags": {
+				Computed:    true,
+				Type:        schema.TypeMap,
+				Description: "Tags of the snapshot.",
+			},
+			"result_output_file": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				Description: "Used to save results.",
+			},
+		},
+	}
+}
+
+func dataSourceTencentCloudCfsSnapshotRead(d *schema.ResourceData, meta interface{}) error {
+	defer logElapsed("data_source.tencentcloud_cfs_snapshot.read")()
+	defer inconsistentCheck(d, meta)()
+
+	logId := getLogId(contextNil)
+	ctx := context.WithValue(context.TODO(), logIdKey, logId)
+
+	service := CfsService{client: meta.(*TencentCloudClient).apiV3Conn}
+	snapshotId := d.Get("snapshot_id").(string)
+
+	var snapshot *cfs.SnapshotInfo
+	err := helper.RetryDescribe(ctx, readRetryTimeout, func() error {
+		result, e := service.DescribeCfsSnapshotById(ctx, snapshotId)
+		if e != nil {
+			return e
+		}
+		snapshot = result
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if snapshot == nil {
+		return fmt.Errorf("cfs snapshot `%s` not found in region `%s`", snapshotId,
+			meta.(*TencentCloudClient).apiV3Conn.Region)
+	}
+
+	d.SetId(snapshotId)
+
+	mapping := map[string]interface{}{
+		"snapshot_id": snapshotId,
+	}
+	if snapshot.FileSystemId != nil {
+		_ = d.Set("file_system_id", snapshot.FileSystemId)
+		mapping["file_system_id"] = *snapshot.FileSystemId
+	}
+	if snapshot.SnapshotName != nil {
+		_ = d.Set("snapshot_name", snapshot.SnapshotName)
+		mapping["snapshot_name"] = *snapshot.SnapshotName
+	}
+	if snapshot.Status != nil {
+		_ = d.Set("status", snapshot.Status)
+		mapping["status"] = *snapshot.Status
+	}
+	if snapshot.Size != nil {
+		_ = d.Set("size", int(*snapshot.Size))
+		mapping["size"] = int(*snapshot.Size)
+	}
+	if snapshot.CreationTime != nil {
+		_ = d.Set("create_time", snapshot.CreationTime)
+		mapping["create_time"] = *snapshot.CreationTime
+	}
+	tags := make(map[string]string, len(snapshot.Tags))
+	for _, tag := range snapshot.Tags {
+		if tag.TagKey != nil && tag.TagValue != nil {
+			tags[*tag.TagKey] = *tag.TagValue
+		}
+	}
+	_ = d.Set("tags", tags)
+	mapping["tags"] = tags
+
+	output, ok := d.GetOk("result_output_file")
+	if ok && output.(string) != "" {
+		if e := writeToFile(output.(string), mapping); e != nil {
+			return e
+		}
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package tencentcloud
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccTencentCloudCfsSnapshotDataSource_basic(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCfsSnapshotDataSource,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTencentCloudDataSourceID("data.tencentcloud_cfs_snapshot.snapshot"),
+					resource.TestCheckResourceAttr("data.tencentcloud_cfs_snapshot.snapshot", "file_system_id", "cfs-iobiaxtj"),
+					resource.TestCheckResourceAttr("data.tencentcloud_cfs_snapshot.snapshot", "snapshot_name", "test"),
+					resource.TestCheckResourceAttrSet("data.tencentcloud_cfs_snapshot.snapshot", "status"),
+					resource.TestCheckResourceAttrSet("data.tencentcloud_cfs_snapshot.snapshot", "create_time"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCfsSnapshotDataSource = testAccCfsSnapshot + `
+
+data "tencentcloud_cfs_snapshot" "snapshot" {
+  snapshot_id = tencentcloud_cfs_snapshot.snapshot.id
+}
+
+`
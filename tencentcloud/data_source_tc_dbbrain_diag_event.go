@@ -0,0 +1,213 @@
+/*
+Use this data source to query the dbbrain diagnosis events of an instance
+
+Example Usage
+
+```hcl
+data "tencentcloud_dbbrain_diag_event" "events" {
+	instance_id = "mysql_instance_id"
+	start_time = "2023-02-01 00:00:00"
+	end_time = "2023-02-02 00:00:00"
+	product = "mysql"
+}
+```
+*/
+package tencentcloud
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	dbbrain "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/dbbrain/v20210527"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
+)
+
+func dataSourceTencentCloudDbbrainDiagEvent() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceTencentCloudDbbrainDiagEventRead,
+		Schema: map[string]*schema.Schema{
+			"instance_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "instance id.",
+			},
+
+			"start_time": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "start time of the time range, like `2019-09-10 12:13:14`.",
+			},
+
+			"end_time": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "end time of the time range, like `2019-09-11 12:13:14`, the interval between the end time and the start time can be up to 2 days.",
+			},
+
+			"product": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "product, optional value is `mysql`, `cynosdb`, default is `mysql`.",
+			},
+
+			"list": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "diagnosis event list.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"event_id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "event id.",
+						},
+						"diag_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "diagnosis type.",
+						},
+						"severity": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "severity of the event, from 1 (fatal) to 5 (healthy).",
+						},
+						"outline": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "diagnosis outline.",
+						},
+						"diag_item": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "description of the diagnosis item.",
+						},
+						"start_time": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "start time of the event.",
+						},
+						"end_time": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "end time of the event.",
+						},
+						"metric": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "reserved metric field.",
+						},
+						"region": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "region of the instance.",
+						},
+					},
+				},
+			},
+
+			"result_output_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Used to save results.",
+			},
+		},
+	}
+}
+
+func dataSourceTencentCloudDbbrainDiagEventRead(d *schema.ResourceData, meta interface{}) error {
+	defer logElapsed("data_source.tencentcloud_dbbrain_diag_event.read")()
+	defer inconsistentCheck(d, meta)()
+
+	logId := getLogId(contextNil)
+	ctx := context.WithValue(context.TODO(), logIdKey, logId)
+	var instanceId string
+
+	paramMap := make(map[string]interface{})
+	if v, ok := d.GetOk("instance_id"); ok {
+		paramMap["instance_id"] = helper.String(v.(string))
+		instanceId = v.(string)
+	}
+
+	if v, ok := d.GetOk("start_time"); ok {
+		paramMap["start_time"] = helper.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("end_time"); ok {
+		paramMap["end_time"] = helper.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("product"); ok {
+		paramMap["product"] = helper.String(v.(string))
+	}
+
+	dbbrainService := DbbrainService{client: meta.(*TencentCloudClient).apiV3Conn}
+
+	var events []*dbbrain.DiagHistoryEventItem
+	err := resource.Retry(readRetryTimeout, func() *resource.RetryError {
+		results, e := dbbrainService.DescribeDbbrainDiagEventsByFilter(ctx, paramMap)
+		if e != nil {
+			return retryError(e)
+		}
+		events = results
+		return nil
+	})
+	if err != nil {
+		log.Printf("[CRITAL]%s read Dbbrain diag events failed, reason:%+v", logId, err)
+		return err
+	}
+
+	ids := make([]string, 0, len(events))
+	eventList := make([]map[string]interface{}, 0, len(events))
+
+	if events != nil {
+
+		for _, event := range events {
+			// an event without an id cannot be keyed into the data source id
+			if event.EventId == nil {
+				continue
+			}
+			eventMap := map[string]interface{}{
+				"event_id": event.EventId,
+			}
+			if event.DiagType != nil {
+				eventMap["diag_type"] = event.DiagType
+			}
+			if event.Severity != nil {
+				eventMap["severity"] = event.Severity
+			}
+			if event.Outline != nil {
+				eventMap["outline"] = event.Outline
+			}
+			if event.DiagItem != nil {
+				eventMap["diag_item"] = event.DiagItem
+			}
+			if event.StartTime != nil {
+				eventMap["start_time"] = event.StartTime
+			}
+			if event.EndTime != nil {
+				eventMap["end_time"] = event.EndTime
+			}
+			if event.Metric != nil {
+				eventMap["metric"] = event.Metric
+			}
+			if event.Region != nil {
+				eventMap["region"] = event.Region
+			}
+			ids = append(ids, instanceId+FILED_SP+helper.Int64ToStr(*event.EventId))
+			eventList = append(eventList, eventMap)
+		}
+		d.SetId(helper.DataResourceIdsHash(ids))
+		_ = d.Set("list", eventList)
+	}
+
+	output, ok := d.GetOk("result_output_file")
+	if ok && output.(string) != "" {
+		if e := writeToFile(output.(string), eventList); e != nil {
+			return e
+		}
+	}
+
+	return nil
+}
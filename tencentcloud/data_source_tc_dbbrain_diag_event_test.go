@@ -0,0 +1,42 @@
+package tencentcloud
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccTencentCloudDbbrainDiagEventDataSource(t *testing.T) {
+	t.Parallel()
+	startTime := time.Now().AddDate(0, 0, -1).Format("2006-01-02 15:04:05")
+	endTime := time.Now().Format("2006-01-02 15:04:05")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceDbbrainDiagEvent(startTime, endTime),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTencentCloudDataSourceID("data.tencentcloud_dbbrain_diag_event.events"),
+					resource.TestCheckResourceAttrSet("data.tencentcloud_dbbrain_diag_event.events", "list.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceDbbrainDiagEvent(st, et string) string {
+	return fmt.Sprintf(`%s
+
+data "tencentcloud_dbbrain_diag_event" "events" {
+  instance_id = local.mysql_id
+  start_time  = "%s"
+  end_time    = "%s"
+  product     = "mysql"
+}
+
+`, CommonPresetMysql, st, et)
+}
@@ -50,10 +50,11 @@ func dataSourceTencentCloudDbbrainSecurityAuditLogExportTasks() *schema.Resource
 			"async_request_ids": {
 				Type: schema.TypeSet,
 				Elem: &schema.Schema{
-					Type: schema.TypeInt,
+					Type:         schema.TypeInt,
+					ValidateFunc: validateIntegerMin(1),
 				},
 				Optional:    true,
-				Description: "async request id list.",
+				Description: "async request id list, each id must be a positive integer.",
 			},
 
 			"list": {
@@ -124,10 +125,37 @@ func dataSourceTencentCloudDbbrainSecurityAuditLogExportTasks() *schema.Resource
 				Optional:    true,
 				Description: "Used to save results.",
 			},
+
+			"result_output_pretty": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the saved results are written as indented JSON. Set to `false` to write compact JSON.",
+			},
 		},
 	}
 }
 
+// dbbrainAsyncRequestIds converts the configured id set to API parameters,
+// defensively dropping duplicates and non-positive values; validation has
+// already rejected the latter at plan time.
+func dbbrainAsyncRequestIds(configured []interface{}) []*uint64 {
+	seen := make(map[int]struct{}, len(configured))
+	ids := make([]*uint64, 0, len(configured))
+	for _, v := range configured {
+		id := v.(int)
+		if id <= 0 {
+			continue
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, helper.IntUint64(id))
+	}
+	return ids
+}
+
 func dataSourceTencentCloudDbbrainSecurityAuditLogExportTasksRead(d *schema.ResourceData, meta interface{}) error {
 	defer logElapsed("data_source.tencentcloud_dbbrain_security_audit_log_export_tasks.read")()
 	defer inconsistentCheck(d, meta)()
@@ -147,13 +175,7 @@ func dataSourceTencentCloudDbbrainSecurityAuditLogExportTasksRead(d *schema.Reso
 	}
 
 	if v, ok := d.GetOk("async_request_ids"); ok {
-		async_request_idSet := v.(*schema.Set).List()
-		tmpList := make([]*uint64, 0, len(async_request_idSet))
-		for i := range async_request_idSet {
-			async_request_id := async_request_idSet[i].(int)
-			tmpList = append(tmpList, helper.IntUint64(async_request_id))
-		}
-		paramMap["async_request_ids"] = tmpList
+		paramMap["async_request_ids"] = dbbrainAsyncRequestIds(v.(*schema.Set).List())
 	}
 
 	dbbrainService := DbbrainService{client: meta.(*TencentCloudClient).apiV3Conn}
@@ -218,7 +240,11 @@ func dataSourceTencentCloudDbbrainSecurityAuditLogExportTasksRead(d *schema.Reso
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := writeToFile(output.(string), taskList); e != nil {
+		indent := ""
+		if d.Get("result_output_pretty").(bool) {
+			indent = "\t"
+		}
+		if e := writeToFileWithIndent(output.(string), taskList, indent); e != nil {
 			return e
 		}
 	}
@@ -48,3 +48,16 @@ data "tencentcloud_dbbrain_security_audit_log_export_tasks" "tasks" {
 
 `, defaultDbBrainsagId, st, et, defaultDbBrainsagId)
 }
+
+func TestDbbrainAsyncRequestIds(t *testing.T) {
+	// duplicates collapse and non-positive ids are dropped
+	ids := dbbrainAsyncRequestIds([]interface{}{3, 3, -1, 0, 5})
+	if len(ids) != 2 || *ids[0] != 3 || *ids[1] != 5 {
+		t.Errorf("unexpected ids: %v", ids)
+	}
+
+	// a negative id is already rejected at plan time
+	if _, errs := validateIntegerMin(1)(-1, "async_request_ids"); len(errs) == 0 {
+		t.Error("expected a negative async request id to fail validation")
+	}
+}
@@ -53,6 +53,18 @@ func dataSourceTencentCloudMariadbDbInstances() *schema.Resource {
 				Description: "instance name or vip.",
 			},
 
+			"instance_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "instance name to search by, supports fuzzy match.",
+			},
+
+			"tags": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "tags to filter by, only instances carrying all given tags are returned.",
+			},
+
 			"vpc_id": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -81,6 +93,11 @@ func dataSourceTencentCloudMariadbDbInstances() *schema.Resource {
 							Computed:    true,
 							Description: "instance name.",
 						},
+						"status": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "instance status.",
+						},
 						"project_id": {
 							Type:        schema.TypeInt,
 							Computed:    true,
@@ -153,6 +170,25 @@ func dataSourceTencentCloudMariadbDbInstances() *schema.Resource {
 	}
 }
 
+// mariadbInstanceMatchTags reports whether an instance carries all the
+// requested tag pairs; an empty filter matches every instance.
+func mariadbInstanceMatchTags(resourceTags []*mariadb.ResourceTag, filter map[string]string) bool {
+	for k, v := range filter {
+		found := false
+		for _, resourceTag := range resourceTags {
+			if resourceTag.TagKey != nil && *resourceTag.TagKey == k &&
+				resourceTag.TagValue != nil && *resourceTag.TagValue == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
 func dataSourceTencentCloudMariadbDbInstancesRead(d *schema.ResourceData, meta interface{}) error {
 	defer logElapsed("data_source.tencentcloud_mariadb_db_instances.read")()
 	defer inconsistentCheck(d, meta)()
@@ -185,6 +221,19 @@ func dataSourceTencentCloudMariadbDbInstancesRead(d *schema.ResourceData, meta i
 		paramMap["search_name"] = helper.String(v.(string))
 	}
 
+	if v, ok := d.GetOk("instance_name"); ok {
+		paramMap["instance_name"] = helper.String(v.(string))
+	}
+
+	tagFilter := helper.GetTags(d, "tags")
+	if len(tagFilter) > 0 {
+		tagKeys := make([]*string, 0, len(tagFilter))
+		for k := range tagFilter {
+			tagKeys = append(tagKeys, helper.String(k))
+		}
+		paramMap["tag_keys"] = tagKeys
+	}
+
 	if v, ok := d.GetOk("vpc_id"); ok {
 		paramMap["vpc_id"] = helper.String(v.(string))
 	}
@@ -211,6 +260,10 @@ func dataSourceTencentCloudMariadbDbInstancesRead(d *schema.ResourceData, meta i
 	instanceList := make([]map[string]interface{}, 0, len(instances))
 	if instances != nil {
 		for _, instance := range instances {
+			// the API only filters by tag keys, the values are matched here
+			if !mariadbInstanceMatchTags(instance.ResourceTags, tagFilter) {
+				continue
+			}
 			instanceMap := map[string]interface{}{}
 			if instance.InstanceId != nil {
 				instanceMap["instance_id"] = instance.InstanceId
@@ -218,6 +271,9 @@ func dataSourceTencentCloudMariadbDbInstancesRead(d *schema.ResourceData, meta i
 			if instance.InstanceName != nil {
 				instanceMap["instance_name"] = instance.InstanceName
 			}
+			if instance.Status != nil {
+				instanceMap["status"] = *instance.Status
+			}
 			if instance.ProjectId != nil {
 				instanceMap["project_id"] = *instance.ProjectId
 			}
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	mariadb "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/mariadb/v20170312"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
 )
 
 // go test -i; go test -test.run TestAccTencentCloudMariadbDbInstancesDataSource -v
@@ -29,3 +31,51 @@ const testAccDataSourceMariadbDbInstances = `
 data "tencentcloud_mariadb_db_instances" "db_instances" {}
 
 `
+
+// go test -i; go test -test.run TestAccTencentCloudMariadbDbInstancesDataSource_byName -v
+func TestAccTencentCloudMariadbDbInstancesDataSource_byName(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceMariadbDbInstancesByName,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTencentCloudDataSourceID("data.tencentcloud_mariadb_db_instances.db_instances_by_name"),
+					resource.TestCheckResourceAttrSet("data.tencentcloud_mariadb_db_instances.db_instances_by_name", "instances.0.instance_id"),
+					resource.TestCheckResourceAttrSet("data.tencentcloud_mariadb_db_instances.db_instances_by_name", "instances.0.status"),
+				),
+			},
+		},
+	})
+}
+
+func TestMariadbInstanceMatchTags(t *testing.T) {
+	resourceTags := []*mariadb.ResourceTag{
+		{TagKey: helper.String("env"), TagValue: helper.String("prod")},
+		{TagKey: helper.String("team"), TagValue: helper.String("db")},
+	}
+
+	if !mariadbInstanceMatchTags(resourceTags, map[string]string{"env": "prod"}) {
+		t.Errorf("expected instance tagged env=prod to match the env=prod filter")
+	}
+	if mariadbInstanceMatchTags(resourceTags, map[string]string{"env": "dev"}) {
+		t.Errorf("expected instance tagged env=prod not to match the env=dev filter")
+	}
+	if mariadbInstanceMatchTags(nil, map[string]string{"env": "prod"}) {
+		t.Errorf("expected untagged instance not to match the env=prod filter")
+	}
+	if !mariadbInstanceMatchTags(resourceTags, nil) {
+		t.Errorf("expected an empty filter to match any instance")
+	}
+}
+
+const testAccDataSourceMariadbDbInstancesByName = `
+
+data "tencentcloud_mariadb_db_instances" "db_instances_by_name" {
+	instance_name = "keep-mariadb"
+}
+
+`
@@ -36,11 +36,28 @@ func dataSourceTencentCloudProtocolTemplates() *schema.Resource {
 				Optional:    true,
 				Description: "ID of the protocol template to query.",
 			},
+			"ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "IDs of the protocol templates to query. Resolved in a single request together with `id`.",
+			},
+			"tags": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Tags of the protocol template to query. Only templates carrying all given tags are returned.",
+			},
 			"result_output_file": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Description: "Used to save results.",
 			},
+			"result_output_pretty": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the saved results are written as indented JSON. Set to `false` to write compact JSON.",
+			},
 
 			// Computed values
 			"template_list": {
@@ -67,6 +84,21 @@ func dataSourceTencentCloudProtocolTemplates() *schema.Resource {
 							Computed:    true,
 							Description: "Set of the protocols.",
 						},
+						"protocol_count": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Number of protocol entries in the template, saves counting `protocols` when iterating templates.",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Description of the protocol template, taken from the remark on its protocol entries.",
+						},
+						"tags": {
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Description: "Tags of the protocol template.",
+						},
 					},
 				},
 			},
@@ -74,22 +106,60 @@ func dataSourceTencentCloudProtocolTemplates() *schema.Resource {
 	}
 }
 
+// protocolTemplateIdValues merges the singular `id` argument with the `ids`
+// set into one deduplicated value list for the service-template-id filter.
+func protocolTemplateIdValues(id string, ids []interface{}) []*string {
+	seen := make(map[string]bool, len(ids)+1)
+	values := make([]*string, 0, len(ids)+1)
+	if id != "" {
+		seen[id] = true
+		values = append(values, helper.String(id))
+	}
+	for _, v := range ids {
+		templateId := v.(string)
+		if templateId == "" || seen[templateId] {
+			continue
+		}
+		seen[templateId] = true
+		values = append(values, helper.String(templateId))
+	}
+	return values
+}
+
+// matchProtocolTemplateTags reports whether a template carries all the
+// requested tag pairs; an empty filter matches every template.
+func matchProtocolTemplateTags(tags, filter map[string]string) bool {
+	for k, v := range filter {
+		if tags[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
 func dataSourceTencentCloudProtocolTemplatesRead(d *schema.ResourceData, meta interface{}) error {
 	defer logElapsed("data_source.tencentcloud_protocol_templates.read")()
 
 	logId := getLogId(contextNil)
 	ctx := context.WithValue(context.TODO(), logIdKey, logId)
 
-	var name, templateId string
+	var name string
 	var filters = make([]*vpc.Filter, 0)
 	if v, ok := d.GetOk("name"); ok {
 		name = v.(string)
 		filters = append(filters, &vpc.Filter{Name: helper.String("service-template-name"), Values: []*string{&name}})
 	}
 
+	var templateId string
+	var configuredIds []interface{}
 	if v, ok := d.GetOk("id"); ok {
 		templateId = v.(string)
-		filters = append(filters, &vpc.Filter{Name: helper.String("service-template-id"), Values: []*string{&templateId}})
+	}
+	if v, ok := d.GetOk("ids"); ok {
+		configuredIds = v.(*schema.Set).List()
+	}
+	if values := protocolTemplateIdValues(templateId, configuredIds); len(values) > 0 {
+		filters = append(filters, &vpc.Filter{Name: helper.String("service-template-id"), Values: values})
 	}
 
 	vpcService := VpcService{client: meta.(*TencentCloudClient).apiV3Conn}
@@ -109,13 +179,35 @@ func dataSourceTencentCloudProtocolTemplatesRead(d *schema.ResourceData, meta in
 		return outErr
 	}
 
+	tagFilter := helper.GetTags(d, "tags")
+	tagService := TagService{client: meta.(*TencentCloudClient).apiV3Conn}
+	region := meta.(*TencentCloudClient).apiV3Conn.Region
+
+	// one batched lookup for the whole template list instead of one tag call
+	// per template
+	templateIds := make([]string, 0, len(templates))
+	for _, ins := range templates {
+		templateIds = append(templateIds, *ins.ServiceTemplateId)
+	}
+	tagsByTemplate, err := tagService.DescribeResourceTagsByResourceIds(ctx, "vpc", "ppm", region, templateIds)
+	if err != nil {
+		return err
+	}
+
 	ids := make([]string, 0, len(templates))
 	templateList := make([]map[string]interface{}, 0, len(templates))
 	for _, ins := range templates {
+		respTags := tagsByTemplate[*ins.ServiceTemplateId]
+		if !matchProtocolTemplateTags(respTags, tagFilter) {
+			continue
+		}
 		mapping := map[string]interface{}{
-			"id":        ins.ServiceTemplateId,
-			"name":      ins.ServiceTemplateName,
-			"protocols": ins.ServiceSet,
+			"id":             ins.ServiceTemplateId,
+			"name":           ins.ServiceTemplateName,
+			"protocols":      ins.ServiceSet,
+			"protocol_count": len(ins.ServiceSet),
+			"description":    serviceTemplateDescription(ins.ServiceExtraSet),
+			"tags":           respTags,
 		}
 		templateList = append(templateList, mapping)
 		ids = append(ids, *ins.ServiceTemplateId)
@@ -128,7 +220,11 @@ func dataSourceTencentCloudProtocolTemplatesRead(d *schema.ResourceData, meta in
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
-		if e := writeToFile(output.(string), templateList); e != nil {
+		indent := ""
+		if d.Get("result_output_pretty").(bool) {
+			indent = "\t"
+		}
+		if e := writeToFileWithIndent(output.(string), templateList, indent); e != nil {
 			return e
 		}
 	}
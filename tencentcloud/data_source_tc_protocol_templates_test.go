@@ -1,9 +1,12 @@
 package tencentcloud
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	vpc "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/vpc/v20170312"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
 )
 
 var testDataProtocolTemplatesNameAll = "data.tencentcloud_protocol_templates.all_test"
@@ -22,6 +25,8 @@ func TestAccTencentCloudDataProtocolTemplates(t *testing.T) {
 					resource.TestCheckResourceAttrSet(testDataProtocolTemplatesNameAll, "template_list.0.id"),
 					resource.TestCheckResourceAttr(testDataProtocolTemplatesNameAll, "template_list.0.name", "testacctcrtemplate"),
 					resource.TestCheckResourceAttr(testDataProtocolTemplatesNameAll, "template_list.0.protocols.#", "1"),
+					resource.TestCheckResourceAttrPair(testDataProtocolTemplatesNameAll, "template_list.0.protocol_count",
+						testDataProtocolTemplatesNameAll, "template_list.0.protocols.#"),
 				),
 			},
 		},
@@ -39,3 +44,124 @@ data "tencentcloud_protocol_templates" "all_test" {
 }
 
 `
+
+func TestAccTencentCloudDataProtocolTemplates_ids(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckProtocolTemplateDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTencentCloudDataProtocolTemplatesIds,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.tencentcloud_protocol_templates.by_ids", "template_list.#", "3"),
+					resource.TestCheckResourceAttrSet("data.tencentcloud_protocol_templates.by_ids", "template_list.0.id"),
+					resource.TestCheckResourceAttrSet("data.tencentcloud_protocol_templates.by_ids", "template_list.1.id"),
+					resource.TestCheckResourceAttrSet("data.tencentcloud_protocol_templates.by_ids", "template_list.2.id"),
+				),
+			},
+		},
+	})
+}
+
+const testAccTencentCloudDataProtocolTemplatesIds = `
+resource "tencentcloud_protocol_template" "batch" {
+  count     = 3
+  name      = "testacctcrtemplate-${count.index}"
+  protocols = ["udp:all"]
+}
+
+data "tencentcloud_protocol_templates" "by_ids" {
+  ids = tencentcloud_protocol_template.batch[*].id
+}
+
+`
+
+func TestProtocolTemplateIdValues(t *testing.T) {
+	values := protocolTemplateIdValues("ppm-1", []interface{}{"ppm-2", "ppm-1", "", "ppm-3"})
+	got := make([]string, 0, len(values))
+	for _, v := range values {
+		got = append(got, *v)
+	}
+	want := []string{"ppm-1", "ppm-2", "ppm-3"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+
+	if values := protocolTemplateIdValues("", nil); len(values) != 0 {
+		t.Errorf("expected no values when neither id nor ids is set, got %d", len(values))
+	}
+}
+
+func TestMatchProtocolTemplateTags(t *testing.T) {
+	tags := map[string]string{"env": "prod", "team": "infra"}
+
+	if !matchProtocolTemplateTags(tags, map[string]string{"env": "prod"}) {
+		t.Errorf("expected template tagged env=prod to match the env=prod filter")
+	}
+	if matchProtocolTemplateTags(tags, map[string]string{"env": "dev"}) {
+		t.Errorf("expected template tagged env=prod not to match the env=dev filter")
+	}
+	if matchProtocolTemplateTags(map[string]string{}, map[string]string{"env": "prod"}) {
+		t.Errorf("expected untagged template not to match the env=prod filter")
+	}
+	// an empty filter matches everything
+	if !matchProtocolTemplateTags(tags, nil) {
+		t.Errorf("expected an empty filter to match any template")
+	}
+}
+
+func TestCollectServiceTemplatePages(t *testing.T) {
+	template := func(id string) *vpc.ServiceTemplate {
+		return &vpc.ServiceTemplate{ServiceTemplateId: helper.String(id)}
+	}
+
+	// a full first page is followed up and both pages are returned in order
+	pages := [][]*vpc.ServiceTemplate{
+		{template("ppm-1"), template("ppm-2")},
+		{template("ppm-3")},
+	}
+	fetches := 0
+	templates, err := collectServiceTemplatePages(2, func(offset, limit int) ([]*vpc.ServiceTemplate, error) {
+		if want := fetches * limit; offset != want {
+			t.Errorf("expected offset %d on fetch %d, got %d", want, fetches, offset)
+		}
+		page := pages[fetches]
+		fetches++
+		return page, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fetches != 2 || len(templates) != 3 {
+		t.Fatalf("expected 3 templates over 2 fetches, got %d over %d", len(templates), fetches)
+	}
+	for i, want := range []string{"ppm-1", "ppm-2", "ppm-3"} {
+		if *templates[i].ServiceTemplateId != want {
+			t.Errorf("expected template %d to be %s, got %s", i, want, *templates[i].ServiceTemplateId)
+		}
+	}
+
+	// a short first page ends the walk without a second fetch
+	fetches = 0
+	templates, err = collectServiceTemplatePages(2, func(offset, limit int) ([]*vpc.ServiceTemplate, error) {
+		fetches++
+		return []*vpc.ServiceTemplate{template("ppm-1")}, nil
+	})
+	if err != nil || fetches != 1 || len(templates) != 1 {
+		t.Errorf("expected a single fetch for a short page, got %d fetches, %d templates, err %v", fetches, len(templates), err)
+	}
+
+	// a page error is surfaced
+	if _, err := collectServiceTemplatePages(2, func(offset, limit int) ([]*vpc.ServiceTemplate, error) {
+		return nil, fmt.Errorf("describe failed")
+	}); err == nil {
+		t.Error("expected the fetch error to be surfaced")
+	}
+}
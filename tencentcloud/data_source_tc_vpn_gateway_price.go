@@ -0,0 +1,196 @@
+/*
+Use this data source to query the price of a VPN gateway before creating it.
+
+Example Usage
+
+```hcl
+data "tencentcloud_vpn_gateway_price" "price" {
+  bandwidth      = 10
+  charge_type    = "PREPAID"
+  prepaid_period = 12
+}
+```
+*/
+package tencentcloud
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	vpc "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/vpc/v20170312"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/ratelimit"
+)
+
+func dataSourceTencentCloudVpnGatewayPrice() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceTencentCloudVpnGatewayPriceRead,
+
+		Schema: map[string]*schema.Schema{
+			"bandwidth": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     5,
+				Description: "The maximum public network output bandwidth of the VPN gateway (unit: Mbps), the available values include: 5,10,20,50,100. Default is 5.",
+			},
+			"charge_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      VPN_CHARGE_TYPE_POSTPAID_BY_HOUR,
+				ValidateFunc: validateAllowedStringValue(VPN_CHARGE_TYPE),
+				Description:  "Charge type of the VPN gateway. Valid value: `PREPAID`, `POSTPAID_BY_HOUR`. The default is `POSTPAID_BY_HOUR`.",
+			},
+			"prepaid_period": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validateAllowedIntValue([]int{1, 2, 3, 4, 6, 7, 8, 9, 12, 24, 36}),
+				Description:  "Period of the prepaid VPN gateway to inquiry. Valid value: `1`, `2`, `3`, `4`, `6`, `7`, `8`, `9`, `12`, `24`, `36`. The unit is month. Only valid when charge type is `PREPAID`.",
+			},
+			"result_output_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Used to save results.",
+			},
+			// computed
+			"instance_price": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Price of the VPN gateway instance.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"unit_price": {
+							Type:        schema.TypeFloat,
+							Computed:    true,
+							Description: "Postpaid unit price (unit: CNY).",
+						},
+						"charge_unit": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Postpaid charge unit. Valid value: `HOUR`, `GB`.",
+						},
+						"original_price": {
+							Type:        schema.TypeFloat,
+							Computed:    true,
+							Description: "Original price of the prepaid VPN gateway (unit: CNY).",
+						},
+						"discount_price": {
+							Type:        schema.TypeFloat,
+							Computed:    true,
+							Description: "Discount price of the prepaid VPN gateway (unit: CNY).",
+						},
+					},
+				},
+			},
+			"bandwidth_price": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Price of the VPN gateway bandwidth.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"unit_price": {
+							Type:        schema.TypeFloat,
+							Computed:    true,
+							Description: "Postpaid unit price (unit: CNY).",
+						},
+						"charge_unit": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Postpaid charge unit. Valid value: `HOUR`, `GB`.",
+						},
+						"original_price": {
+							Type:        schema.TypeFloat,
+							Computed:    true,
+							Description: "Original price of the prepaid bandwidth (unit: CNY).",
+						},
+						"discount_price": {
+							Type:        schema.TypeFloat,
+							Computed:    true,
+							Description: "Discount price of the prepaid bandwidth (unit: CNY).",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTencentCloudVpnGatewayPriceRead(d *schema.ResourceData, meta interface{}) error {
+	defer logElapsed("data_source.tencentcloud_vpn_gateway_price.read")()
+
+	logId := getLogId(contextNil)
+
+	request := vpc.NewInquiryPriceCreateVpnGatewayRequest()
+	bandwidth := uint64(d.Get("bandwidth").(int))
+	request.InternetMaxBandwidthOut = &bandwidth
+	chargeType := d.Get("charge_type").(string)
+	request.InstanceChargeType = &chargeType
+	if chargeType == VPN_CHARGE_TYPE_PREPAID {
+		var preChargePara vpc.InstanceChargePrepaid
+		preChargePara.Period = helper.IntUint64(d.Get("prepaid_period").(int))
+		request.InstanceChargePrepaid = &preChargePara
+	}
+
+	var response *vpc.InquiryPriceCreateVpnGatewayResponse
+	err := resource.Retry(readRetryTimeout, func() *resource.RetryError {
+		ratelimit.Check(request.GetAction())
+		result, e := meta.(*TencentCloudClient).apiV3Conn.UseVpcClient().InquiryPriceCreateVpnGateway(request)
+		if e != nil {
+			log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
+				logId, request.GetAction(), request.ToJsonString(), e.Error())
+			return retryError(e)
+		}
+		response = result
+		return nil
+	})
+	if err != nil {
+		log.Printf("[CRITAL]%s inquiry VPN gateway price failed, reason:%s\n", logId, err.Error())
+		return err
+	}
+
+	if response.Response == nil || response.Response.Price == nil {
+		return fmt.Errorf("VPN gateway price is nil")
+	}
+	price := response.Response.Price
+
+	flattenItemPrice := func(item *vpc.ItemPrice) []map[string]interface{} {
+		if item == nil {
+			return nil
+		}
+		mapping := map[string]interface{}{}
+		if item.UnitPrice != nil {
+			mapping["unit_price"] = *item.UnitPrice
+		}
+		if item.ChargeUnit != nil {
+			mapping["charge_unit"] = *item.ChargeUnit
+		}
+		if item.OriginalPrice != nil {
+			mapping["original_price"] = *item.OriginalPrice
+		}
+		if item.DiscountPrice != nil {
+			mapping["discount_price"] = *item.DiscountPrice
+		}
+		return []map[string]interface{}{mapping}
+	}
+
+	instancePrice := flattenItemPrice(price.InstancePrice)
+	bandwidthPrice := flattenItemPrice(price.BandwidthPrice)
+	_ = d.Set("instance_price", instancePrice)
+	_ = d.Set("bandwidth_price", bandwidthPrice)
+
+	d.SetId(helper.DataResourceIdHash(fmt.Sprintf("%s-%d-%d", chargeType, bandwidth, d.Get("prepaid_period").(int))))
+
+	output, ok := d.GetOk("result_output_file")
+	if ok && output.(string) != "" {
+		if e := writeToFile(output.(string), map[string]interface{}{
+			"instance_price":  instancePrice,
+			"bandwidth_price": bandwidthPrice,
+		}); e != nil {
+			return e
+		}
+	}
+
+	return nil
+}
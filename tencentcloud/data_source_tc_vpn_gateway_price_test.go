@@ -0,0 +1,32 @@
+package tencentcloud
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccTencentCloudVpnGatewayPriceDataSource(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTencentCloudVpnGatewayPriceDataSourceConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTencentCloudDataSourceID("data.tencentcloud_vpn_gateway_price.price"),
+					resource.TestCheckResourceAttrSet("data.tencentcloud_vpn_gateway_price.price", "instance_price.0.original_price"),
+				),
+			},
+		},
+	})
+}
+
+const testAccTencentCloudVpnGatewayPriceDataSourceConfig_basic = `
+data "tencentcloud_vpn_gateway_price" "price" {
+  bandwidth      = 10
+  charge_type    = "PREPAID"
+  prepaid_period = 12
+}
+`
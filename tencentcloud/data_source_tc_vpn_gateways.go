@@ -73,6 +73,22 @@ func dataSourceTencentCloudVpnGateways() *schema.Resource {
 			},
 
 			// Computed values
+			"total_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of VPN gateways matching the query.",
+			},
+			"total_bandwidth": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Sum of the maximum public network output bandwidth over the matching gateways (unit: Mbps).",
+			},
+			"count_by_type": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "Number of matching VPN gateways per gateway instance type.",
+			},
 			"gateway_list": {
 				Type:        schema.TypeList,
 				Computed:    true,
@@ -166,6 +182,18 @@ func dataSourceTencentCloudVpnGateways() *schema.Resource {
 	}
 }
 
+// vpnGatewayAggregates sums the bandwidth over the matching gateways and
+// counts them per instance type, for capacity snapshots without
+// post-processing the list.
+func vpnGatewayAggregates(gatewayList []map[string]interface{}) (totalBandwidth int, countByType map[string]int) {
+	countByType = make(map[string]int)
+	for _, gateway := range gatewayList {
+		totalBandwidth += gateway["bandwidth"].(int)
+		countByType[gateway["type"].(string)]++
+	}
+	return
+}
+
 func dataSourceTencentCloudVpnGatewaysRead(d *schema.ResourceData, meta interface{}) error {
 	defer logElapsed("data_source.tencentcloud_vpn_gateways.read")()
 
@@ -272,6 +300,10 @@ func dataSourceTencentCloudVpnGatewaysRead(d *schema.ResourceData, meta interfac
 		log.Printf("[CRITAL]%s provider set gateway list fail, reason:%s\n ", logId, e.Error())
 		return e
 	}
+	totalBandwidth, countByType := vpnGatewayAggregates(gatewayList)
+	_ = d.Set("total_count", len(gatewayList))
+	_ = d.Set("total_bandwidth", totalBandwidth)
+	_ = d.Set("count_by_type", countByType)
 
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
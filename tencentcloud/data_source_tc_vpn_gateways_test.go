@@ -20,6 +20,10 @@ func TestAccTencentCloudVpnGatewaysDataSource(t *testing.T) {
 					resource.TestCheckResourceAttr("data.tencentcloud_vpn_gateways.cgws", "gateway_list.0.name", "terraform_test"),
 					resource.TestCheckResourceAttr("data.tencentcloud_vpn_gateways.cgws", "gateway_list.0.bandwidth", "10"),
 					resource.TestCheckResourceAttr("data.tencentcloud_vpn_gateways.cgws", "gateway_list.0.tags.test", "tf"),
+					// the aggregates must match the single returned gateway
+					resource.TestCheckResourceAttr("data.tencentcloud_vpn_gateways.cgws", "total_count", "1"),
+					resource.TestCheckResourceAttr("data.tencentcloud_vpn_gateways.cgws", "total_bandwidth", "10"),
+					resource.TestCheckResourceAttr("data.tencentcloud_vpn_gateways.cgws", "count_by_type.IPSEC", "1"),
 				),
 			},
 		},
@@ -47,3 +51,24 @@ data "tencentcloud_vpn_gateways" "cgws" {
   id = tencentcloud_vpn_gateway.my_cgw.id
 }
 `
+
+func TestVpnGatewayAggregates(t *testing.T) {
+	gatewayList := []map[string]interface{}{
+		{"type": "IPSEC", "bandwidth": 10},
+		{"type": "IPSEC", "bandwidth": 100},
+		{"type": "SSL", "bandwidth": 5},
+	}
+	totalBandwidth, countByType := vpnGatewayAggregates(gatewayList)
+	if totalBandwidth != 115 {
+		t.Errorf("expected a total bandwidth of 115, got %d", totalBandwidth)
+	}
+	if countByType["IPSEC"] != 2 || countByType["SSL"] != 1 {
+		t.Errorf("unexpected per-type counts: %v", countByType)
+	}
+
+	// no gateways means zero totals and an empty breakdown
+	totalBandwidth, countByType = vpnGatewayAggregates(nil)
+	if totalBandwidth != 0 || len(countByType) != 0 {
+		t.Errorf("expected empty aggregates, got %d and %v", totalBandwidth, countByType)
+	}
+}
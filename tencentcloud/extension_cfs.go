@@ -9,6 +9,8 @@ const (
 
 	CFS_FILE_SYSTEM_STATUS_CREATING = "creating"
 	CFS_FILE_SYSTEM_STATUS_SUCCESS  = "available"
+
+	CFS_SNAPSHOT_STATUS_ROLLBACKING = "rollbacking"
 	CFS_FILE_SYSTEM_STATUS_FAILED   = "create_failed"
 
 	CFS_RW_PERMISSION_RO = "RO"
@@ -43,3 +45,7 @@ var CFS_USER_PERMISSION = []string{
 }
 
 const CfsInvalidPgroup = "InvalidParameterValue.InvalidPgroup"
+
+// returned when the file system is in the middle of another operation and
+// cannot take a snapshot yet
+const CfsInvalidFsStatus = "InvalidParameterValue.InvalidFsStatus"
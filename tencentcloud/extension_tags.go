@@ -1,3 +1,6 @@
 package tencentcloud
 
 const DESCRIBE_TAGS_LIMIT = 20
+
+// ModifyResourceTags accepts at most 10 tag operations per call
+const MODIFY_TAGS_LIMIT = 10
@@ -16,6 +16,9 @@ const GATE_WAY_TYPE_EIP = "EIP"
 const GATE_WAY_TYPE_CCN = "CCN"
 const GATE_WAY_TYPE_LOCAL_GATEWAY = "LOCAL_GATEWAY"
 
+// state of an SSL VPN client whose tunnel is currently established
+const SSL_VPN_CLIENT_STATE_CONNECTED = "6"
+
 var ALL_GATE_WAY_TYPES = []string{
 	GATE_WAY_TYPE_CVM,
 	GATE_WAY_TYPE_VPN,
@@ -58,9 +61,16 @@ const (
 
 	EIP_RESOURCE_TYPE = "eip"
 
+	EIP_INTERNET_CHARGE_TYPE_BANDWIDTH_PACKAGE = "BANDWIDTH_PACKAGE"
+	EIP_INTERNET_CHARGE_TYPE_PREPAID_BY_MONTH  = "BANDWIDTH_PREPAID_BY_MONTH"
+
 	EIP_TASK_STATUS_SUCCESS = "SUCCESS"
 	EIP_TASK_STATUS_RUNNING = "RUNNING"
 	EIP_TASK_STATUS_FAILED  = "FAILED"
+
+	// DisassociateAddress answers for an EIP that is no longer bound
+	EIP_UNATTACH_NOT_PERMIT        = "InvalidAddressIdStatus.NotPermit"
+	EIP_UNATTACH_STATUS_NOT_PERMIT = "UnsupportedOperation.AddressStatusNotPermit"
 )
 
 var EIP_INTERNET_PROVIDER = []string{
@@ -166,6 +176,12 @@ var VPN_CHARGE_TYPE = []string{
 	VPN_CHARGE_TYPE_POSTPAID_BY_HOUR,
 }
 
+// bandwidth tiers accepted per charge type, prepaid gateways top out at 100 Mbps
+var VPN_BANDWIDTH_BY_CHARGE_TYPE = map[string][]int{
+	VPN_CHARGE_TYPE_PREPAID:          {5, 10, 20, 50, 100},
+	VPN_CHARGE_TYPE_POSTPAID_BY_HOUR: {5, 10, 20, 50, 100, 200, 500, 1000},
+}
+
 const (
 	VPN_PURCHASE_PLAN_PRE_POST = "PREPAID_TO_POSTPAID"
 )
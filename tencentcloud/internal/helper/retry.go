@@ -0,0 +1,56 @@
+package helper
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+// RetryableErrorCodes lists the SDK error codes treated as transient when
+// describing resources; a code matches exactly or by its family prefix, e.g.
+// "FailedOperation" also matches "FailedOperation.SomeDetail".
+var RetryableErrorCodes = []string{
+	// client
+	"ClientError.NetworkError",
+	"ClientError.HttpStatusCodeError",
+	// common
+	"FailedOperation",
+	"TradeUnknownError",
+	"RequestLimitExceeded",
+	"ResourceInUse",
+	"ResourceUnavailable",
+	// cbs
+	"ResourceBusy",
+	// teo
+	"InvalidParameter.ActionInProgress",
+}
+
+// IsRetryableErrorCode reports whether code belongs to the transient error
+// code families of RetryableErrorCodes.
+func IsRetryableErrorCode(code string) bool {
+	for _, c := range RetryableErrorCodes {
+		if code == c {
+			return true
+		}
+		if strings.Contains(code, ".") && strings.Split(code, ".")[0] == c {
+			return true
+		}
+	}
+	return false
+}
+
+// RetryDescribe runs fn with the standard read-retry policy: transient SDK
+// errors are retried until timeout, anything else fails immediately.
+func RetryDescribe(ctx context.Context, timeout time.Duration, fn func() error) error {
+	return resource.Retry(timeout, func() *resource.RetryError {
+		if err := fn(); err != nil {
+			if sdkErr := UnwarpSDKError(err); sdkErr != nil && IsRetryableErrorCode(sdkErr.Code) {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,63 @@
+package helper
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	sdkErrors "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/errors"
+)
+
+func TestIsRetryableErrorCode(t *testing.T) {
+	tests := []struct {
+		code      string
+		retryable bool
+	}{
+		{"ClientError.NetworkError", true},
+		{"FailedOperation", true},
+		{"FailedOperation.SomeDetail", true},
+		{"RequestLimitExceeded", true},
+		{"InvalidParameter.ActionInProgress", true},
+		{"ResourceNotFound", false},
+		{"InvalidParameterValue", false},
+		{"InternalError", false},
+	}
+	for _, tt := range tests {
+		if got := IsRetryableErrorCode(tt.code); got != tt.retryable {
+			t.Errorf("IsRetryableErrorCode(%q) = %v, want %v", tt.code, got, tt.retryable)
+		}
+	}
+}
+
+func TestRetryDescribe(t *testing.T) {
+	ctx := context.TODO()
+
+	// a transient error is retried until fn succeeds
+	calls := 0
+	err := RetryDescribe(ctx, 10*time.Second, func() error {
+		calls++
+		if calls < 2 {
+			return sdkErrors.NewTencentCloudSDKError("ClientError.NetworkError", "", "")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expected transient error to be retried to success, got: %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected 2 calls, got %d", calls)
+	}
+
+	// a non-transient error fails immediately
+	calls = 0
+	err = RetryDescribe(ctx, 10*time.Second, func() error {
+		calls++
+		return sdkErrors.NewTencentCloudSDKError("ResourceNotFound", "", "")
+	})
+	if err == nil {
+		t.Fatalf("expected a non-transient error to be returned")
+	}
+	if calls != 1 {
+		t.Fatalf("expected a single call for a non-transient error, got %d", calls)
+	}
+}
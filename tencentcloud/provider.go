@@ -226,6 +226,7 @@ Cloud File Storage(CFS)
 	tencentcloud_cfs_mount_targets
 	tencentcloud_cfs_file_system_clients
 	tencentcloud_cfs_available_zone
+	tencentcloud_cfs_snapshot
 
   Resource
     tencentcloud_cfs_file_system
@@ -234,6 +235,7 @@ Cloud File Storage(CFS)
 	tencentcloud_cfs_auto_snapshot_policy
 	tencentcloud_cfs_auto_snapshot_policy_attachment
 	tencentcloud_cfs_snapshot
+	tencentcloud_cfs_snapshots
 	tencentcloud_cfs_sign_up_cfs_service
 
 Container Cluster
@@ -664,6 +666,7 @@ VPN Connections(VPN)
     tencentcloud_vpn_connections
     tencentcloud_vpn_customer_gateways
     tencentcloud_vpn_gateways
+    tencentcloud_vpn_gateway_price
     tencentcloud_vpn_gateway_routes
 
   Resource
@@ -861,6 +864,7 @@ TencentDB for DBbrain(dbbrain)
   Data Source
 	tencentcloud_dbbrain_sql_filters
 	tencentcloud_dbbrain_security_audit_log_export_tasks
+	tencentcloud_dbbrain_diag_event
   Resource
 	tencentcloud_dbbrain_sql_filter
 	tencentcloud_dbbrain_security_audit_log_export_task
@@ -931,17 +935,25 @@ Tencent Service Framework(TSF)
   Resource
 	tencentcloud_tsf_microservice
 	tencentcloud_tsf_application_config
+	tencentcloud_tsf_application_config_release
 
 */
 package tencentcloud
 
 import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
 	"net/url"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/mitchellh/go-homedir"
 	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
 	sts "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/sts/v20180813"
 	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/connectivity"
@@ -959,10 +971,19 @@ const (
 	PROVIDER_ASSUME_ROLE_ARN              = "TENCENTCLOUD_ASSUME_ROLE_ARN"
 	PROVIDER_ASSUME_ROLE_SESSION_NAME     = "TENCENTCLOUD_ASSUME_ROLE_SESSION_NAME"
 	PROVIDER_ASSUME_ROLE_SESSION_DURATION = "TENCENTCLOUD_ASSUME_ROLE_SESSION_DURATION"
+	PROVIDER_ASSUME_ROLE_EXTERNAL_ID      = "TENCENTCLOUD_ASSUME_ROLE_EXTERNAL_ID"
+	PROVIDER_SHARED_CREDENTIALS_FILE      = "TENCENTCLOUD_SHARED_CREDENTIALS_FILE"
+	PROVIDER_PROFILE                      = "TENCENTCLOUD_PROFILE"
 )
 
+const DEFAULT_PROFILE = "default"
+
 type TencentCloudClient struct {
 	apiV3Conn *connectivity.TencentCloudClient
+
+	// regions probed by importers when a resource is not found in the
+	// configured region; empty unless `import_fallback_regions` is set
+	importFallbackRegions []string
 }
 
 func Provider() terraform.ResourceProvider {
@@ -970,17 +991,29 @@ func Provider() terraform.ResourceProvider {
 		Schema: map[string]*schema.Schema{
 			"secret_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc(PROVIDER_SECRET_ID, nil),
-				Description: "This is the TencentCloud access key. It must be provided, but it can also be sourced from the `TENCENTCLOUD_SECRET_ID` environment variable.",
+				Description: "This is the TencentCloud access key. It can also be sourced from the `TENCENTCLOUD_SECRET_ID` environment variable or the shared credentials file.",
 			},
 			"secret_key": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc(PROVIDER_SECRET_KEY, nil),
-				Description: "This is the TencentCloud secret key. It must be provided, but it can also be sourced from the `TENCENTCLOUD_SECRET_KEY` environment variable.",
+				Description: "This is the TencentCloud secret key. It can also be sourced from the `TENCENTCLOUD_SECRET_KEY` environment variable or the shared credentials file.",
 				Sensitive:   true,
 			},
+			"shared_credentials_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc(PROVIDER_SHARED_CREDENTIALS_FILE, nil),
+				Description: "The path of the shared credentials file, used when `secret_id` and `secret_key` are not set explicitly or through environment variables. It can also be sourced from the `TENCENTCLOUD_SHARED_CREDENTIALS_FILE` environment variable.",
+			},
+			"profile": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc(PROVIDER_PROFILE, nil),
+				Description: "The profile name to use within the shared credentials file. It can also be sourced from the `TENCENTCLOUD_PROFILE` environment variable. Default is `default`.",
+			},
 			"security_token": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -990,11 +1023,17 @@ func Provider() terraform.ResourceProvider {
 			},
 			"region": {
 				Type:         schema.TypeString,
-				Required:     true,
+				Optional:     true,
 				DefaultFunc:  schema.EnvDefaultFunc(PROVIDER_REGION, nil),
-				Description:  "This is the TencentCloud region. It must be provided, but it can also be sourced from the `TENCENTCLOUD_REGION` environment variables. The default input value is ap-guangzhou.",
+				Description:  "This is the TencentCloud region. It can also be sourced from the `TENCENTCLOUD_REGION` environment variables, and accepts a comma-separated preference list whose first non-empty entry is used. The default input value is ap-guangzhou.",
 				InputDefault: "ap-guangzhou",
 			},
+			"region_from_instance_metadata": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to read the region from the CVM instance metadata service when no region is configured. Default is `false`.",
+			},
 			"protocol": {
 				Type:         schema.TypeString,
 				Optional:     true,
@@ -1008,6 +1047,24 @@ func Provider() terraform.ResourceProvider {
 				DefaultFunc: schema.EnvDefaultFunc(PROVIDER_DOMAIN, nil),
 				Description: "The root domain of the API request, Default is `tencentcloudapi.com`.",
 			},
+			"import_fallback_regions": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "List of regions probed when an imported resource is not found in the configured region, to report where it actually lives. Empty by default, so imports make no extra API calls.",
+			},
+			"verify_permissions": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to run a lightweight permission pre-check during provider configuration, issuing one read-only describe call per core service and failing fast when a required read permission is missing. Default is `false`.",
+			},
+			"api_rate_limits": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Per-action QPS limits applied to API requests, as a map from a service file name (e.g. `service_tencentcloud_vpc`) or `<service file name>.<Action>` (e.g. `service_tencentcloud_vpc.DescribeVpnGateways`) to the allowed requests per second. Keys not listed keep the built-in limits.",
+			},
 			"assume_role": {
 				Type:        schema.TypeSet,
 				Optional:    true,
@@ -1036,14 +1093,20 @@ func Provider() terraform.ResourceProvider {
 								}
 								return 7200, nil
 							},
-							ValidateFunc: validateIntegerInRange(0, 43200),
-							Description:  "The duration of the session when making the AssumeRole call. Its value ranges from 0 to 43200(seconds), and default is 7200 seconds. It can be sourced from the `TENCENTCLOUD_ASSUME_ROLE_SESSION_DURATION`.",
+							ValidateFunc: validateIntegerInRange(900, 43200),
+							Description:  "The duration of the session when making the AssumeRole call. Its value ranges from 900 to 43200(seconds), and default is 7200 seconds. It can be sourced from the `TENCENTCLOUD_ASSUME_ROLE_SESSION_DURATION`.",
 						},
 						"policy": {
 							Type:        schema.TypeString,
 							Optional:    true,
 							Description: "A more restrictive policy when making the AssumeRole call. Its content must not contains `principal` elements. Notice: more syntax references, please refer to: [policies syntax logic](https://intl.cloud.tencent.com/document/product/598/10603).",
 						},
+						"external_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							DefaultFunc: schema.EnvDefaultFunc(PROVIDER_ASSUME_ROLE_EXTERNAL_ID, nil),
+							Description: "External role ID, which can be obtained by clicking the role name in the CAM console. It can contain 2-128 letters, digits, and symbols (=,.@:/-). Regex: [\\w+=,.@:/-]*. It can be sourced from the `TENCENTCLOUD_ASSUME_ROLE_EXTERNAL_ID`.",
+						},
 					},
 				},
 			},
@@ -1081,6 +1144,7 @@ func Provider() terraform.ResourceProvider {
 			"tencentcloud_nat_gateway_snats":                        dataSourceTencentCloudNatGatewaySnats(),
 			"tencentcloud_vpn_customer_gateways":                    dataSourceTencentCloudVpnCustomerGateways(),
 			"tencentcloud_vpn_gateways":                             dataSourceTencentCloudVpnGateways(),
+			"tencentcloud_vpn_gateway_price":                        dataSourceTencentCloudVpnGatewayPrice(),
 			"tencentcloud_vpn_gateway_routes":                       dataSourceTencentCloudVpnGatewayRoutes(),
 			"tencentcloud_vpn_connections":                          dataSourceTencentCloudVpnConnections(),
 			"tencentcloud_ha_vips":                                  dataSourceTencentCloudHaVips(),
@@ -1114,6 +1178,7 @@ func Provider() terraform.ResourceProvider {
 			"tencentcloud_cfs_mount_targets":                        dataSourceTencentCloudCfsMountTargets(),
 			"tencentcloud_cfs_file_system_clients":                  dataSourceTencentCloudCfsFileSystemClients(),
 			"tencentcloud_cfs_available_zone":                       dataSourceTencentCloudCfsAvailableZone(),
+			"tencentcloud_cfs_snapshot":                             dataSourceTencentCloudCfsSnapshot(),
 			"tencentcloud_redis_zone_config":                        dataSourceTencentRedisZoneConfig(),
 			"tencentcloud_redis_instances":                          dataSourceTencentRedisInstances(),
 			"tencentcloud_as_scaling_configs":                       dataSourceTencentCloudAsScalingConfigs(),
@@ -1260,6 +1325,7 @@ func Provider() terraform.ResourceProvider {
 			"tencentcloud_tat_invoker":                              dataSourceTencentCloudTatInvoker(),
 			"tencentcloud_dbbrain_sql_filters":                      dataSourceTencentCloudDbbrainSqlFilters(),
 			"tencentcloud_dbbrain_security_audit_log_export_tasks":  dataSourceTencentCloudDbbrainSecurityAuditLogExportTasks(),
+			"tencentcloud_dbbrain_diag_event":                       dataSourceTencentCloudDbbrainDiagEvent(),
 			"tencentcloud_dts_sync_jobs":                            dataSourceTencentCloudDtsSyncJobs(),
 			"tencentcloud_dts_compare_tasks":                        dataSourceTencentCloudDtsCompareTasks(),
 			"tencentcloud_dts_migrate_jobs":                         dataSourceTencentCloudDtsMigrateJobs(),
@@ -1372,6 +1438,7 @@ func Provider() terraform.ResourceProvider {
 			"tencentcloud_cfs_auto_snapshot_policy":                 resourceTencentCloudCfsAutoSnapshotPolicy(),
 			"tencentcloud_cfs_auto_snapshot_policy_attachment":      resourceTencentCloudCfsAutoSnapshotPolicyAttachment(),
 			"tencentcloud_cfs_snapshot":                             resourceTencentCloudCfsSnapshot(),
+			"tencentcloud_cfs_snapshots":                            resourceTencentCloudCfsSnapshots(),
 			"tencentcloud_cfs_user_quota":                           resourceTencentCloudCfsUserQuota(),
 			"tencentcloud_cfs_sign_up_cfs_service":                  resourceTencentCloudCfsSignUpCfsService(),
 			"tencentcloud_redis_instance":                           resourceTencentCloudRedisInstance(),
@@ -1652,6 +1719,7 @@ func Provider() terraform.ResourceProvider {
 			"tencentcloud_dayu_ddos_ip_attachment_v2":                 resourceTencentCloudDayuDDosIpAttachmentV2(),
 			"tencentcloud_tsf_microservice":                           resourceTencentCloudTsfMicroservice(),
 			"tencentcloud_tsf_application_config":                     resourceTencentCloudTsfApplicationConfig(),
+			"tencentcloud_tsf_application_config_release":             resourceTencentCloudTsfApplicationConfigRelease(),
 			"tencentcloud_cvm_launch_template":                        resourceTencentCloudCvmLaunchTemplate(),
 		},
 
@@ -1663,10 +1731,52 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 	secretId := d.Get("secret_id").(string)
 	secretKey := d.Get("secret_key").(string)
 	securityToken := d.Get("security_token").(string)
-	region := d.Get("region").(string)
+
+	// explicit configuration and environment variables take precedence over
+	// the shared credentials file, which only fills in what is still missing
+	if secretId == "" || secretKey == "" {
+		if v, ok := d.GetOk("shared_credentials_file"); ok {
+			profile := DEFAULT_PROFILE
+			if p, ok := d.GetOk("profile"); ok {
+				profile = p.(string)
+			}
+			fileSecretId, fileSecretKey, fileSecurityToken, err := loadSharedCredentials(v.(string), profile)
+			if err != nil {
+				return nil, err
+			}
+			if secretId == "" {
+				secretId = fileSecretId
+			}
+			if secretKey == "" {
+				secretKey = fileSecretKey
+			}
+			if securityToken == "" {
+				securityToken = fileSecurityToken
+			}
+		}
+	}
+
+	if secretId == "" || secretKey == "" {
+		return nil, fmt.Errorf("`secret_id` and `secret_key` must be set through provider configuration, environment variables or the shared credentials file")
+	}
+
+	region, err := resolveProviderRegion(d.Get("region").(string), d.Get("region_from_instance_metadata").(bool), fetchInstanceMetadataRegion)
+	if err != nil {
+		return nil, err
+	}
 	protocol := d.Get("protocol").(string)
 	domain := d.Get("domain").(string)
 
+	if v, ok := d.GetOk("api_rate_limits"); ok {
+		for key, limit := range v.(map[string]interface{}) {
+			qps, err := strconv.ParseInt(limit.(string), 10, 64)
+			if err != nil || qps <= 0 {
+				return nil, fmt.Errorf("invalid QPS limit %v of `api_rate_limits` key %s, a positive integer is expected", limit, key)
+			}
+			ratelimit.SetLimit(key, qps)
+		}
+	}
+
 	// standard client
 	var tcClient TencentCloudClient
 	tcClient.apiV3Conn = &connectivity.TencentCloudClient{
@@ -1680,6 +1790,12 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		Domain:   domain,
 	}
 
+	if v, ok := d.GetOk("import_fallback_regions"); ok {
+		for _, r := range v.([]interface{}) {
+			tcClient.importFallbackRegions = append(tcClient.importFallbackRegions, r.(string))
+		}
+	}
+
 	envRoleArn := os.Getenv(PROVIDER_ASSUME_ROLE_ARN)
 	envSessionName := os.Getenv(PROVIDER_ASSUME_ROLE_SESSION_NAME)
 
@@ -1697,7 +1813,7 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 			assumeRoleSessionDuration = 7200
 		}
 
-		_ = genClientWithSTS(&tcClient, envRoleArn, envSessionName, assumeRoleSessionDuration, "")
+		_ = genClientWithSTS(&tcClient, envRoleArn, envSessionName, assumeRoleSessionDuration, "", os.Getenv(PROVIDER_ASSUME_ROLE_EXTERNAL_ID))
 	}
 
 	// get assume role from tf config
@@ -1708,14 +1824,191 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		assumeRoleSessionName := assumeRole["session_name"].(string)
 		assumeRoleSessionDuration := assumeRole["session_duration"].(int)
 		assumeRolePolicy := assumeRole["policy"].(string)
+		assumeRoleExternalId := assumeRole["external_id"].(string)
+
+		_ = genClientWithSTS(&tcClient, assumeRoleArn, assumeRoleSessionName, assumeRoleSessionDuration, assumeRolePolicy, assumeRoleExternalId)
+	}
 
-		_ = genClientWithSTS(&tcClient, assumeRoleArn, assumeRoleSessionName, assumeRoleSessionDuration, assumeRolePolicy)
+	if d.Get("verify_permissions").(bool) {
+		if err := runPermissionPreChecks(permissionPreChecks(&tcClient)); err != nil {
+			return nil, err
+		}
 	}
 	return &tcClient, nil
 }
 
-func genClientWithSTS(tcClient *TencentCloudClient, assumeRoleArn, assumeRoleSessionName string, assumeRoleSessionDuration int, assumeRolePolicy string) error {
-	// applying STS credentials
+// permissionPreCheck is one read-only probe run when `verify_permissions` is
+// enabled; action names the permission it exercises.
+type permissionPreCheck struct {
+	action string
+	run    func() error
+}
+
+// permissionPreChecks lists the describe calls probed per core service when
+// `verify_permissions` is enabled
+func permissionPreChecks(tcClient *TencentCloudClient) []permissionPreCheck {
+	ctx := context.WithValue(context.TODO(), logIdKey, getLogId(contextNil))
+	cvmService := CvmService{client: tcClient.apiV3Conn}
+	vpcService := VpcService{client: tcClient.apiV3Conn}
+	camService := CamService{client: tcClient.apiV3Conn}
+	return []permissionPreCheck{
+		{"cvm:DescribeRegions", func() error {
+			_, err := cvmService.DescribeRegions(ctx)
+			return err
+		}},
+		{"vpc:DescribeVpcs", func() error {
+			_, err := vpcService.DescribeVpcs(ctx, "", "", nil, nil, "", "")
+			return err
+		}},
+		{"cam:ListPolicies", func() error {
+			_, err := camService.DescribePoliciesByFilter(ctx, map[string]interface{}{})
+			return err
+		}},
+	}
+}
+
+// runPermissionPreChecks executes each pre-check in order and fails fast
+// naming the action whose permission is missing
+func runPermissionPreChecks(checks []permissionPreCheck) error {
+	for _, check := range checks {
+		if err := check.run(); err != nil {
+			return fmt.Errorf("permission pre-check `%s` failed, the configured credentials lack a required read permission: %v", check.action, err)
+		}
+	}
+	return nil
+}
+
+// resolveProviderRegion picks the effective region from a comma-separated
+// preference list, taking the first non-empty entry. When nothing is
+// configured and `region_from_instance_metadata` is enabled, the region is
+// read through metadataRegion instead, so modules can run unchanged on a CVM
+// in any region.
+func resolveProviderRegion(configured string, fromMetadata bool, metadataRegion func() (string, error)) (string, error) {
+	for _, candidate := range strings.Split(configured, ",") {
+		if candidate = strings.TrimSpace(candidate); candidate != "" {
+			return candidate, nil
+		}
+	}
+	if fromMetadata {
+		region, err := metadataRegion()
+		if err != nil {
+			return "", fmt.Errorf("`region_from_instance_metadata` is enabled but reading the region from instance metadata failed: %v", err)
+		}
+		if region = strings.TrimSpace(region); region != "" {
+			return region, nil
+		}
+	}
+	return "", fmt.Errorf("`region` must be set through provider configuration, the %s environment variable or instance metadata", PROVIDER_REGION)
+}
+
+// fetchInstanceMetadataRegion reads the region of the current CVM instance
+// from the metadata service, which is only reachable from inside a CVM.
+func fetchInstanceMetadataRegion() (string, error) {
+	client := &http.Client{Timeout: 2 * time.Second}
+	resp, err := client.Get("http://metadata.tencentyun.com/latest/meta-data/placement/region")
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("instance metadata service responded with status %d", resp.StatusCode)
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+// describeAcrossRegions probes the given fallback regions for a resource an
+// import did not find in the configured region. probe is called with a client
+// bound to each candidate region and reports whether the resource exists
+// there. The region the resource lives in is returned, or an empty string
+// when no probed region has it. The configured region itself is skipped.
+func describeAcrossRegions(client *connectivity.TencentCloudClient, regions []string, probe func(regionClient *connectivity.TencentCloudClient) (bool, error)) (string, error) {
+	for _, region := range regions {
+		if region == "" || region == client.Region {
+			continue
+		}
+		regionClient := &connectivity.TencentCloudClient{
+			Credential: client.Credential,
+			Region:     region,
+			Protocol:   client.Protocol,
+			Domain:     client.Domain,
+		}
+		has, err := probe(regionClient)
+		if err != nil {
+			return "", err
+		}
+		if has {
+			return region, nil
+		}
+	}
+	return "", nil
+}
+
+// loadSharedCredentials reads the credentials of the given profile from an
+// ini style shared credentials file, e.g.
+//
+//	[default]
+//	secret_id = AKIDxxx
+//	secret_key = xxx
+func loadSharedCredentials(filePath, profile string) (secretId, secretKey, securityToken string, err error) {
+	fileName, err := homedir.Expand(filePath)
+	if err != nil {
+		return
+	}
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return
+	}
+
+	section := ""
+	found := false
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			continue
+		}
+		if section != profile {
+			continue
+		}
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		key := strings.TrimSpace(parts[0])
+		value := strings.TrimSpace(parts[1])
+		// values are commonly quoted in ini files; a quoted secret must not
+		// keep its quotes
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		switch key {
+		case "secret_id":
+			secretId = value
+			found = true
+		case "secret_key":
+			secretKey = value
+			found = true
+		case "security_token":
+			securityToken = value
+			found = true
+		}
+	}
+	if !found {
+		err = fmt.Errorf("profile `%s` not found in shared credentials file `%s`", profile, fileName)
+	}
+	return
+}
+
+// buildAssumeRoleRequest assembles the STS AssumeRole request from the
+// provider-level assume_role configuration.
+func buildAssumeRoleRequest(assumeRoleArn, assumeRoleSessionName string, assumeRoleSessionDuration int, assumeRolePolicy, assumeRoleExternalId string) *sts.AssumeRoleRequest {
 	request := sts.NewAssumeRoleRequest()
 	request.RoleArn = helper.String(assumeRoleArn)
 	request.RoleSessionName = helper.String(assumeRoleSessionName)
@@ -1723,6 +2016,15 @@ func genClientWithSTS(tcClient *TencentCloudClient, assumeRoleArn, assumeRoleSes
 	if assumeRolePolicy != "" {
 		request.Policy = helper.String(url.QueryEscape(assumeRolePolicy))
 	}
+	if assumeRoleExternalId != "" {
+		request.ExternalId = helper.String(assumeRoleExternalId)
+	}
+	return request
+}
+
+func genClientWithSTS(tcClient *TencentCloudClient, assumeRoleArn, assumeRoleSessionName string, assumeRoleSessionDuration int, assumeRolePolicy, assumeRoleExternalId string) error {
+	// applying STS credentials
+	request := buildAssumeRoleRequest(assumeRoleArn, assumeRoleSessionName, assumeRoleSessionDuration, assumeRolePolicy, assumeRoleExternalId)
 	ratelimit.Check(request.GetAction())
 	response, err := tcClient.apiV3Conn.UseStsClient().AssumeRole(request)
 	if err != nil {
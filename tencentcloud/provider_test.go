@@ -2,8 +2,11 @@ package tencentcloud
 
 import (
 	"fmt"
+	"io/ioutil"
 	"log"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/connectivity"
@@ -182,3 +185,197 @@ func testAccPreCheckBusiness(t *testing.T, accountType string) {
 		testAccPreCheck(t)
 	}
 }
+
+func TestBuildAssumeRoleRequest(t *testing.T) {
+	request := buildAssumeRoleRequest("qcs::cam::uin/100012345678:roleName/test", "tf-session", 3600, "", "external-id-123")
+
+	if *request.RoleArn != "qcs::cam::uin/100012345678:roleName/test" {
+		t.Fatalf("unexpected role arn: %s", *request.RoleArn)
+	}
+	if *request.RoleSessionName != "tf-session" {
+		t.Fatalf("unexpected session name: %s", *request.RoleSessionName)
+	}
+	if *request.DurationSeconds != 3600 {
+		t.Fatalf("unexpected session duration: %d", *request.DurationSeconds)
+	}
+	if request.Policy != nil {
+		t.Fatalf("policy should not be set when empty")
+	}
+	if request.ExternalId == nil || *request.ExternalId != "external-id-123" {
+		t.Fatalf("external id did not reach the STS request: %v", request.ExternalId)
+	}
+
+	// external_id is optional, the request must not carry an empty one
+	request = buildAssumeRoleRequest("qcs::cam::uin/100012345678:roleName/test", "tf-session", 3600, "{}", "")
+	if request.ExternalId != nil {
+		t.Fatalf("external id should not be set when empty")
+	}
+	if request.Policy == nil {
+		t.Fatalf("policy should be set")
+	}
+}
+
+func TestLoadSharedCredentials(t *testing.T) {
+	credentialsFile := filepath.Join(t.TempDir(), "credentials")
+	content := `
+# shared credentials used by the provider tests
+[default]
+secret_id = AKIDdefault
+secret_key = defaultKey
+
+[test]
+secret_id = "AKIDtest"
+secret_key = 'testKey'
+security_token = testToken
+`
+	if err := ioutil.WriteFile(credentialsFile, []byte(content), 0600); err != nil {
+		t.Fatalf("write credentials file failed: %v", err)
+	}
+
+	secretId, secretKey, securityToken, err := loadSharedCredentials(credentialsFile, "default")
+	if err != nil {
+		t.Fatalf("load default profile failed: %v", err)
+	}
+	if secretId != "AKIDdefault" || secretKey != "defaultKey" || securityToken != "" {
+		t.Fatalf("unexpected default profile credentials: %s/%s/%s", secretId, secretKey, securityToken)
+	}
+
+	secretId, secretKey, securityToken, err = loadSharedCredentials(credentialsFile, "test")
+	if err != nil {
+		t.Fatalf("load test profile failed: %v", err)
+	}
+	if secretId != "AKIDtest" || secretKey != "testKey" || securityToken != "testToken" {
+		t.Fatalf("unexpected test profile credentials: %s/%s/%s", secretId, secretKey, securityToken)
+	}
+
+	if _, _, _, err = loadSharedCredentials(credentialsFile, "missing"); err == nil {
+		t.Fatalf("expected an error for a missing profile")
+	}
+
+	if _, _, _, err = loadSharedCredentials(filepath.Join(t.TempDir(), "nonexistent"), "default"); err == nil {
+		t.Fatalf("expected an error for a missing credentials file")
+	}
+}
+
+func TestDescribeAcrossRegions(t *testing.T) {
+	client := &connectivity.TencentCloudClient{Region: "ap-guangzhou"}
+	fallbacks := []string{"ap-guangzhou", "ap-shanghai", "ap-singapore"}
+
+	probed := make([]string, 0)
+	region, err := describeAcrossRegions(client, fallbacks, func(regionClient *connectivity.TencentCloudClient) (bool, error) {
+		probed = append(probed, regionClient.Region)
+		return regionClient.Region == "ap-shanghai", nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if region != "ap-shanghai" {
+		t.Errorf("expected the resource to be found in ap-shanghai, got %q", region)
+	}
+	// the configured region is skipped, probing stops at the first hit
+	if len(probed) != 1 || probed[0] != "ap-shanghai" {
+		t.Errorf("unexpected probed regions: %v", probed)
+	}
+
+	// a resource living nowhere reports an empty region
+	region, err = describeAcrossRegions(client, fallbacks, func(regionClient *connectivity.TencentCloudClient) (bool, error) {
+		return false, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if region != "" {
+		t.Errorf("expected no region, got %q", region)
+	}
+
+	// without configured fallback regions no probe is made
+	calls := 0
+	if _, err := describeAcrossRegions(client, nil, func(regionClient *connectivity.TencentCloudClient) (bool, error) {
+		calls++
+		return true, nil
+	}); err != nil || calls != 0 {
+		t.Errorf("expected no probes without fallback regions, got %d calls, err %v", calls, err)
+	}
+}
+
+func TestRunPermissionPreChecks(t *testing.T) {
+	// every configured probe runs when they all pass
+	ran := make([]string, 0)
+	checks := []permissionPreCheck{
+		{"cvm:DescribeRegions", func() error { ran = append(ran, "cvm"); return nil }},
+		{"vpc:DescribeVpcs", func() error { ran = append(ran, "vpc"); return nil }},
+	}
+	if err := runPermissionPreChecks(checks); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ran) != 2 {
+		t.Errorf("expected both pre-checks to run, got %v", ran)
+	}
+
+	// a failing probe stops the run and the error names its action
+	ran = ran[:0]
+	checks = []permissionPreCheck{
+		{"cvm:DescribeRegions", func() error { ran = append(ran, "cvm"); return fmt.Errorf("denied") }},
+		{"vpc:DescribeVpcs", func() error { ran = append(ran, "vpc"); return nil }},
+	}
+	err := runPermissionPreChecks(checks)
+	if err == nil {
+		t.Fatal("expected an error for a denied pre-check")
+	}
+	if !strings.Contains(err.Error(), "cvm:DescribeRegions") {
+		t.Errorf("expected the error to name the failing action, got: %v", err)
+	}
+	if len(ran) != 1 {
+		t.Errorf("expected the run to stop at the first failure, got %v", ran)
+	}
+
+	// the provider wires one probe per core service
+	client := &TencentCloudClient{apiV3Conn: &connectivity.TencentCloudClient{Region: "ap-guangzhou"}}
+	if got := len(permissionPreChecks(client)); got != 3 {
+		t.Errorf("expected 3 configured pre-checks, got %d", got)
+	}
+}
+
+func TestResolveProviderRegion(t *testing.T) {
+	noMetadata := func() (string, error) {
+		t.Fatal("metadata should not be queried when a region is configured")
+		return "", nil
+	}
+
+	// a plain region is used as is
+	region, err := resolveProviderRegion("ap-guangzhou", false, noMetadata)
+	if err != nil || region != "ap-guangzhou" {
+		t.Errorf("expected ap-guangzhou, got %q, err %v", region, err)
+	}
+
+	// the first non-empty entry of a preference list wins
+	region, err = resolveProviderRegion(" , ap-shanghai, ap-guangzhou", true, noMetadata)
+	if err != nil || region != "ap-shanghai" {
+		t.Errorf("expected ap-shanghai, got %q, err %v", region, err)
+	}
+
+	// without a configured region the instance metadata is consulted
+	region, err = resolveProviderRegion("", true, func() (string, error) {
+		return "ap-singapore", nil
+	})
+	if err != nil || region != "ap-singapore" {
+		t.Errorf("expected ap-singapore from metadata, got %q, err %v", region, err)
+	}
+
+	// a metadata failure is surfaced instead of silently falling through
+	_, err = resolveProviderRegion("", true, func() (string, error) {
+		return "", fmt.Errorf("metadata service unreachable")
+	})
+	if err == nil || !strings.Contains(err.Error(), "metadata") {
+		t.Errorf("expected a metadata error, got %v", err)
+	}
+
+	// with the flag off an empty region is rejected without touching metadata
+	_, err = resolveProviderRegion("", false, func() (string, error) {
+		t.Fatal("metadata should not be queried when the flag is off")
+		return "", nil
+	})
+	if err == nil {
+		t.Error("expected an error for a missing region")
+	}
+}
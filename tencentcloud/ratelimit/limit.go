@@ -60,6 +60,22 @@ func ProCheck(namespace, action string) {
 
 }
 
+// SetLimit overrides the QPS limit of the given key, either a bare namespace
+// (the service file name without its ".go" suffix) or "namespace.Action".
+// Any limiter already built for the key is dropped so the new limit takes
+// effect on the next check. Non-positive limits are ignored.
+func SetLimit(key string, qps int64) {
+	if qps <= 0 {
+		return
+	}
+
+	locker.Lock()
+	defer locker.Unlock()
+
+	limitConfig[key] = qps
+	delete(limitContainer, key)
+}
+
 func Check(action string) {
 
 	_, filePath, _, _ := runtime.Caller(1)
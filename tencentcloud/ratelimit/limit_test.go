@@ -0,0 +1,32 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSetLimitEnforced(t *testing.T) {
+	SetLimit("limit_test.FakeAction", 1)
+
+	// the bucket starts full, the first call passes immediately
+	ProCheck("limit_test", "FakeAction")
+
+	// with a limit of 1 QPS the second call has to wait for the bucket to refill
+	start := time.Now()
+	ProCheck("limit_test", "FakeAction")
+	if elapsed := time.Since(start); elapsed < 800*time.Millisecond {
+		t.Errorf("second call returned after %v, expected it to wait about a second for 1 QPS", elapsed)
+	}
+}
+
+func TestSetLimitIgnoresNonPositive(t *testing.T) {
+	SetLimit("limit_test.Ignored", 0)
+	SetLimit("limit_test.Ignored", -1)
+
+	locker.Lock()
+	_, ok := limitConfig["limit_test.Ignored"]
+	locker.Unlock()
+	if ok {
+		t.Errorf("non-positive limits should not be stored")
+	}
+}
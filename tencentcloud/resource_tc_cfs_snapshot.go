@@ -32,7 +32,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	cfs "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cfs/v20190719"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/connectivity"
 	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/ratelimit"
 )
 
 func resourceTencentCloudCfsSnapshot() *schema.Resource {
@@ -42,20 +44,75 @@ func resourceTencentCloudCfsSnapshot() *schema.Resource {
 		Update: resourceTencentCloudCfsSnapshotUpdate,
 		Delete: resourceTencentCloudCfsSnapshotDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				//reject ids of snapshots that do not exist up front instead of importing an empty resource
+				ctx := context.WithValue(context.TODO(), logIdKey, getLogId(contextNil))
+				service := CfsService{client: meta.(*TencentCloudClient).apiV3Conn}
+				snapshot, err := service.DescribeCfsSnapshotById(ctx, d.Id())
+				if err != nil {
+					return nil, err
+				}
+				if snapshot == nil {
+					tcClient := meta.(*TencentCloudClient)
+					if region, pErr := describeAcrossRegions(tcClient.apiV3Conn, tcClient.importFallbackRegions, func(regionClient *connectivity.TencentCloudClient) (bool, error) {
+						regionService := CfsService{client: regionClient}
+						found, e := regionService.DescribeCfsSnapshotById(ctx, d.Id())
+						if e != nil {
+							return false, e
+						}
+						return found != nil, nil
+					}); pErr == nil && region != "" {
+						return nil, fmt.Errorf("cfs snapshot `%s` not found in region `%s`, it lives in region `%s`",
+							d.Id(), tcClient.apiV3Conn.Region, region)
+					}
+					return nil, fmt.Errorf("cfs snapshot `%s` not found in region `%s`, check your provider region",
+						d.Id(), tcClient.apiV3Conn.Region)
+				}
+				return []*schema.ResourceData{d}, nil
+			},
 		},
 		Schema: map[string]*schema.Schema{
 			"file_system_id": {
-				Required:    true,
-				ForceNew:    true,
-				Type:        schema.TypeString,
-				Description: "Id of file system.",
+				Required: true,
+				ForceNew: true,
+				Type:     schema.TypeString,
+				// a snapshot id (snap-xxx) pasted here used to fail with a
+				// cryptic API error, catch it at plan time instead
+				ValidateFunc: validateStringPrefix("cfs-"),
+				Description:  "Id of file system, must start with `cfs-`.",
 			},
 
 			"snapshot_name": {
-				Optional:    true,
+				Optional:     true,
+				Type:         schema.TypeString,
+				ValidateFunc: validateCfsSnapshotName,
+				Description:  "Name of snapshot. Up to 64 characters, only chinese characters, letters, numbers and `-_.()[]` are allowed.",
+			},
+
+			"resource_name": {
+				Computed:    true,
 				Type:        schema.TypeString,
-				Description: "Name of snapshot.",
+				Description: "The fully-qualified QCS resource name of the snapshot, which can be used in CAM policies and tag automation.",
+			},
+
+			"region": {
+				Computed:    true,
+				Type:        schema.TypeString,
+				Description: "The region the snapshot lives in, useful for multi-region module outputs.",
+			},
+
+			"force_destroy": {
+				Optional:    true,
+				Type:        schema.TypeBool,
+				Default:     false,
+				Description: "Whether a delete blocked by a dependent operation (e.g. a pending restore) waits for the blocker to finish. The CFS API offers no way to cancel the dependent operation; without this flag such a delete fails fast naming the blocker.",
+			},
+
+			"deletion_protection": {
+				Optional:    true,
+				Type:        schema.TypeBool,
+				Default:     false,
+				Description: "Whether the snapshot is protected from deletion. A protected snapshot can not be destroyed until this field is set to `false` first.",
 			},
 
 			"tags": {
@@ -87,9 +144,11 @@ func resourceTencentCloudCfsSnapshotCreate(d *schema.ResourceData, meta interfac
 	}
 
 	err := resource.Retry(writeRetryTimeout, func() *resource.RetryError {
+		ratelimit.Check(request.GetAction())
 		result, e := meta.(*TencentCloudClient).apiV3Conn.UseCfsClient().CreateCfsSnapshot(request)
 		if e != nil {
-			return retryError(e)
+			//a file system busy with another operation can take a snapshot once it finishes
+			return retryError(e, CfsInvalidFsStatus)
 		} else {
 			log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n", logId, request.GetAction(), request.ToJsonString(), result.ToJsonString())
 		}
@@ -106,7 +165,7 @@ func resourceTencentCloudCfsSnapshotCreate(d *schema.ResourceData, meta interfac
 
 	service := CfsService{client: meta.(*TencentCloudClient).apiV3Conn}
 
-	conf := BuildStateChangeConf([]string{}, []string{"available"}, 2*readRetryTimeout, time.Second, service.CfsSnapshotStateRefreshFunc(d.Id(), []string{}))
+	conf := BuildStateChangeConfWithBackoff([]string{}, []string{"available"}, 2*readRetryTimeout, time.Second, service.CfsSnapshotStateRefreshFunc(d.Id(), []string{}))
 
 	if _, e := conf.WaitForState(); e != nil {
 		return e
@@ -115,8 +174,13 @@ func resourceTencentCloudCfsSnapshotCreate(d *schema.ResourceData, meta interfac
 	ctx := context.WithValue(context.TODO(), logIdKey, logId)
 	if tags := helper.GetTags(d, "tags"); len(tags) > 0 {
 		tagService := TagService{client: meta.(*TencentCloudClient).apiV3Conn}
+		camService := CamService{client: meta.(*TencentCloudClient).apiV3Conn}
 		region := meta.(*TencentCloudClient).apiV3Conn.Region
-		resourceName := fmt.Sprintf("qcs::cfs:%s:uin/:snap/%s", region, d.Id())
+		uin, err := camService.DescribeAccountUin(ctx)
+		if err != nil {
+			return err
+		}
+		resourceName := BuildTagResourceNameWithUin("cfs", "snap", region, d.Id(), uin)
 		if err := tagService.ModifyTags(ctx, resourceName, tags, nil); err != nil {
 			return err
 		}
@@ -137,7 +201,15 @@ func resourceTencentCloudCfsSnapshotRead(d *schema.ResourceData, meta interface{
 
 	snapshotId := d.Id()
 
-	snapshot, err := service.DescribeCfsSnapshotById(ctx, snapshotId)
+	var snapshot *cfs.SnapshotInfo
+	err := helper.RetryDescribe(ctx, readRetryTimeout, func() error {
+		result, e := service.DescribeCfsSnapshotById(ctx, snapshotId)
+		if e != nil {
+			return e
+		}
+		snapshot = result
+		return nil
+	})
 	if err != nil {
 		return err
 	}
@@ -162,8 +234,21 @@ func resourceTencentCloudCfsSnapshotRead(d *schema.ResourceData, meta interface{
 	if err != nil {
 		return err
 	}
+	tags = normalizeResourceTagsCase(tags, helper.GetTags(d, "tags"))
 	_ = d.Set("tags", tags)
 
+	//export the same uin-filled QCS name the tag calls use, a uin-less name
+	//would not match what CAM policies and tag automation see. Resolving the
+	//uin needs a CAM permission CFS-scoped credentials may lack, so a failed
+	//lookup only skips the attribute instead of failing the refresh
+	camService := CamService{client: tcClient}
+	if uin, uinErr := camService.DescribeAccountUin(ctx); uinErr != nil {
+		log.Printf("[WARN]%s describe account uin failed, resource_name is not refreshed: %+v", logId, uinErr)
+	} else {
+		_ = d.Set("resource_name", BuildTagResourceNameWithUin("cfs", "snap", tcClient.Region, d.Id(), uin))
+	}
+	_ = d.Set("region", tcClient.Region)
+
 	return nil
 }
 
@@ -189,6 +274,7 @@ func resourceTencentCloudCfsSnapshotUpdate(d *schema.ResourceData, meta interfac
 
 	if needChange {
 		err := resource.Retry(writeRetryTimeout, func() *resource.RetryError {
+			ratelimit.Check(request.GetAction())
 			result, e := meta.(*TencentCloudClient).apiV3Conn.UseCfsClient().UpdateCfsSnapshotAttribute(request)
 			if e != nil {
 				return retryError(e)
@@ -201,6 +287,30 @@ func resourceTencentCloudCfsSnapshotUpdate(d *schema.ResourceData, meta interfac
 			log.Printf("[CRITAL]%s update cfs snapshot failed, reason:%+v", logId, err)
 			return err
 		}
+
+		// some accounts reset snapshot tags on rename; re-apply any configured
+		// tags the rename dropped
+		if configured := helper.GetTags(d, "tags"); len(configured) > 0 {
+			ctx := context.WithValue(context.TODO(), logIdKey, logId)
+			tcClient := meta.(*TencentCloudClient).apiV3Conn
+			tagService := &TagService{client: tcClient}
+			actual, err := tagService.DescribeResourceTags(ctx, "cfs", "snap", tcClient.Region, snapshotId)
+			if err != nil {
+				return err
+			}
+			if missing := missingResourceTags(configured, actual); len(missing) > 0 {
+				log.Printf("[WARN]%s cfs snapshot %s lost %d tags on rename, re-applying", logId, snapshotId, len(missing))
+				camService := CamService{client: tcClient}
+				uin, err := camService.DescribeAccountUin(ctx)
+				if err != nil {
+					return err
+				}
+				resourceName := BuildTagResourceNameWithUin("cfs", "snap", tcClient.Region, snapshotId, uin)
+				if err := tagService.ModifyTags(ctx, resourceName, missing, nil); err != nil {
+					return err
+				}
+			}
+		}
 	}
 
 	if d.HasChange("tags") {
@@ -209,7 +319,12 @@ func resourceTencentCloudCfsSnapshotUpdate(d *schema.ResourceData, meta interfac
 		tagService := &TagService{client: tcClient}
 		oldTags, newTags := d.GetChange("tags")
 		replaceTags, deleteTags := diffTags(oldTags.(map[string]interface{}), newTags.(map[string]interface{}))
-		resourceName := BuildTagResourceName("cfs", "snap", tcClient.Region, d.Id())
+		camService := CamService{client: tcClient}
+		uin, err := camService.DescribeAccountUin(ctx)
+		if err != nil {
+			return err
+		}
+		resourceName := BuildTagResourceNameWithUin("cfs", "snap", tcClient.Region, d.Id(), uin)
 		if err := tagService.ModifyTags(ctx, resourceName, replaceTags, deleteTags); err != nil {
 			return err
 		}
@@ -218,6 +333,18 @@ func resourceTencentCloudCfsSnapshotUpdate(d *schema.ResourceData, meta interfac
 	return resourceTencentCloudCfsSnapshotRead(d, meta)
 }
 
+// cfsSnapshotDeleteRetry decides how a delete poll proceeds. A snapshot held
+// by a dependent operation (a pending restore) cannot be deleted and the API
+// offers no way to cancel the blocker, so the poll fails fast naming it
+// unless `force_destroy` asked to wait it out; any other remaining state
+// simply retries until the snapshot is gone.
+func cfsSnapshotDeleteRetry(snapshotId, status string, forceDestroy bool) *resource.RetryError {
+	if status == CFS_SNAPSHOT_STATUS_ROLLBACKING && !forceDestroy {
+		return resource.NonRetryableError(fmt.Errorf("cfs snapshot %s can not be deleted while a restore holds it (status `%s`), wait for it to finish or set `force_destroy` to wait it out", snapshotId, status))
+	}
+	return resource.RetryableError(fmt.Errorf("cfs snapshot status is %s, retry...", status))
+}
+
 func resourceTencentCloudCfsSnapshotDelete(d *schema.ResourceData, meta interface{}) error {
 	defer logElapsed("resource.tencentcloud_cfs_snapshot.delete")()
 	defer inconsistentCheck(d, meta)()
@@ -228,10 +355,15 @@ func resourceTencentCloudCfsSnapshotDelete(d *schema.ResourceData, meta interfac
 	service := CfsService{client: meta.(*TencentCloudClient).apiV3Conn}
 	snapshotId := d.Id()
 
+	if d.Get("deletion_protection").(bool) {
+		return fmt.Errorf("cfs snapshot %s is protected from deletion, set `deletion_protection` to false before destroying it", snapshotId)
+	}
+
 	if err := service.DeleteCfsSnapshotById(ctx, snapshotId); err != nil {
 		return err
 	}
 
+	forceDestroy := d.Get("force_destroy").(bool)
 	err := resource.Retry(2*readRetryTimeout, func() *resource.RetryError {
 		instance, errRet := service.DescribeCfsSnapshotById(ctx, snapshotId)
 		if errRet != nil {
@@ -240,7 +372,7 @@ func resourceTencentCloudCfsSnapshotDelete(d *schema.ResourceData, meta interfac
 		if instance == nil {
 			return nil
 		}
-		return resource.RetryableError(fmt.Errorf("cfs snapshot status is %s, retry...", *instance.Status))
+		return cfsSnapshotDeleteRetry(snapshotId, helper.PString(instance.Status), forceDestroy)
 	})
 	if err != nil {
 		return err
@@ -1,11 +1,67 @@
 package tencentcloud
 
 import (
+	"fmt"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	cfs "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cfs/v20190719"
+	sdkErrors "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/errors"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
 )
 
+func TestValidateCfsSnapshotName(t *testing.T) {
+	tests := []struct {
+		name  string
+		valid bool
+	}{
+		{"a", true},
+		{strings.Repeat("a", 64), true},
+		{strings.Repeat("a", 65), false},
+		{"", false},
+		{"backup-2023_01.01(daily)[v1]", true},
+		{"快照备份", true},
+		{"bad name", false},
+		{"bad/name", false},
+		{"bad#name", false},
+	}
+
+	for _, tt := range tests {
+		_, errors := validateCfsSnapshotName(tt.name, "snapshot_name")
+		if tt.valid && len(errors) > 0 {
+			t.Errorf("expected %q to be valid, got: %v", tt.name, errors)
+		}
+		if !tt.valid && len(errors) == 0 {
+			t.Errorf("expected %q to be rejected", tt.name)
+		}
+	}
+}
+
+func TestCfsSnapshotRefreshState(t *testing.T) {
+	// right after create the snapshot may not be visible yet; the waiter should
+	// see a pending pseudo-state instead of an error
+	obj, status := cfsSnapshotRefreshState(nil)
+	if obj == nil {
+		t.Fatalf("expected a non-nil placeholder object for a not-yet-visible snapshot")
+	}
+	if status != CFS_FILE_SYSTEM_STATUS_CREATING {
+		t.Fatalf("expected pending state %s, got %s", CFS_FILE_SYSTEM_STATUS_CREATING, status)
+	}
+
+	snapshot := &cfs.SnapshotInfo{Status: helper.String(CFS_FILE_SYSTEM_STATUS_SUCCESS)}
+	obj, status = cfsSnapshotRefreshState(snapshot)
+	if obj != snapshot {
+		t.Fatalf("expected the snapshot object to be passed through")
+	}
+	if status != CFS_FILE_SYSTEM_STATUS_SUCCESS {
+		t.Fatalf("expected state %s, got %s", CFS_FILE_SYSTEM_STATUS_SUCCESS, status)
+	}
+}
+
 func TestAccTencentCloudCfsSnapshotResource_basic(t *testing.T) {
 	t.Parallel()
 	resource.Test(t, resource.TestCase{
@@ -16,12 +72,80 @@ func TestAccTencentCloudCfsSnapshotResource_basic(t *testing.T) {
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCfsSnapshot,
-				Check:  resource.ComposeTestCheckFunc(resource.TestCheckResourceAttrSet("tencentcloud_cfs_snapshot.snapshot", "id")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("tencentcloud_cfs_snapshot.snapshot", "id"),
+					// the snapshot self-reports the provider region
+					resource.TestCheckResourceAttr("tencentcloud_cfs_snapshot.snapshot", "region", defaultRegion),
+					resource.TestCheckResourceAttr("tencentcloud_cfs_snapshot.snapshot", "tags.createdBy", "terraform"),
+				),
 			},
 			{
 				ResourceName:      "tencentcloud_cfs_snapshot.snapshot",
 				ImportState:       true,
 				ImportStateVerify: true,
+				// the imported state must carry the real tags, not an empty map
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 {
+						return fmt.Errorf("expected one imported instance, got %d", len(states))
+					}
+					if got := states[0].Attributes["tags.createdBy"]; got != "terraform" {
+						return fmt.Errorf("expected imported tag createdBy=terraform, got %q", got)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccTencentCloudCfsSnapshotResource_importNotFound(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				// importing a bogus snapshot id must fail instead of producing
+				// an empty resource that creates on the next apply
+				Config:        testAccCfsSnapshot,
+				ResourceName:  "tencentcloud_cfs_snapshot.snapshot",
+				ImportState:   true,
+				ImportStateId: "snap-00000000",
+				ExpectError:   regexp.MustCompile("not found in region"),
+			},
+		},
+	})
+}
+
+func TestAccTencentCloudCfsSnapshotResource_deletionProtection(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCfsSnapshotDeletionProtection,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("tencentcloud_cfs_snapshot.snapshot", "id"),
+					resource.TestCheckResourceAttr("tencentcloud_cfs_snapshot.snapshot", "deletion_protection", "true"),
+				),
+			},
+			{
+				// destroying a protected snapshot must fail before the delete API is called
+				Config:      testAccCfsSnapshotDeletionProtection,
+				Destroy:     true,
+				ExpectError: regexp.MustCompile("protected from deletion"),
+			},
+			{
+				// protection must be lifted first so the final destroy succeeds
+				Config: testAccCfsSnapshotDeletionProtectionOff,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tencentcloud_cfs_snapshot.snapshot", "deletion_protection", "false"),
+				),
 			},
 		},
 	})
@@ -38,3 +162,117 @@ resource "tencentcloud_cfs_snapshot" "snapshot" {
 }
 
 `
+
+const testAccCfsSnapshotDeletionProtection = `
+
+resource "tencentcloud_cfs_snapshot" "snapshot" {
+  file_system_id = "cfs-iobiaxtj"
+  snapshot_name = "test-protected"
+  deletion_protection = true
+  tags = {
+    "createdBy" = "terraform"
+  }
+}
+
+`
+
+const testAccCfsSnapshotDeletionProtectionOff = `
+
+resource "tencentcloud_cfs_snapshot" "snapshot" {
+  file_system_id = "cfs-iobiaxtj"
+  snapshot_name = "test-protected"
+  deletion_protection = false
+  tags = {
+    "createdBy" = "terraform"
+  }
+}
+
+`
+
+func TestCfsSnapshotCreateRetryOnBusyFs(t *testing.T) {
+	// a busy file system returns InvalidFsStatus first; the create retry loop
+	// must classify it as retryable so the snapshot succeeds afterwards
+	calls := 0
+	err := resource.Retry(10*time.Second, func() *resource.RetryError {
+		calls++
+		if calls < 2 {
+			return retryError(sdkErrors.NewTencentCloudSDKError(CfsInvalidFsStatus, "file system is busy", ""), CfsInvalidFsStatus)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expected busy-then-success sequence to succeed, got: %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected 2 calls, got %d", calls)
+	}
+
+	// without the addition the same error stays non-retryable
+	calls = 0
+	err = resource.Retry(10*time.Second, func() *resource.RetryError {
+		calls++
+		return retryError(sdkErrors.NewTencentCloudSDKError(CfsInvalidFsStatus, "file system is busy", ""))
+	})
+	if err == nil {
+		t.Fatal("expected the busy error to fail without the additional retryable code")
+	}
+	if calls != 1 {
+		t.Fatalf("expected a single call, got %d", calls)
+	}
+}
+
+func TestMissingResourceTags(t *testing.T) {
+	configured := map[string]string{"createdBy": "terraform", "env": "prod"}
+
+	// rename wiped every tag, all of them must be re-applied
+	missing := missingResourceTags(configured, nil)
+	if len(missing) != 2 {
+		t.Errorf("expected both tags to be re-applied, got %v", missing)
+	}
+
+	// one tag survived with a changed value
+	missing = missingResourceTags(configured, map[string]string{"createdBy": "terraform", "env": "dev"})
+	if len(missing) != 1 || missing["env"] != "prod" {
+		t.Errorf("expected only the drifted tag to be re-applied, got %v", missing)
+	}
+
+	// nothing lost, nothing to do
+	if missing = missingResourceTags(configured, configured); len(missing) != 0 {
+		t.Errorf("expected no tags to re-apply, got %v", missing)
+	}
+}
+
+func TestCfsSnapshotDeleteRetry(t *testing.T) {
+	// a snapshot held by a restore fails fast and names the blocker
+	err := cfsSnapshotDeleteRetry("snap-test", CFS_SNAPSHOT_STATUS_ROLLBACKING, false)
+	if err == nil || err.Retryable {
+		t.Fatalf("expected a non-retryable error for a blocked delete, got %v", err)
+	}
+	if !strings.Contains(err.Err.Error(), CFS_SNAPSHOT_STATUS_ROLLBACKING) || !strings.Contains(err.Err.Error(), "force_destroy") {
+		t.Errorf("expected the error to name the blocker and the escape hatch, got %v", err.Err)
+	}
+
+	// force_destroy waits the restore out instead
+	if err := cfsSnapshotDeleteRetry("snap-test", CFS_SNAPSHOT_STATUS_ROLLBACKING, true); err == nil || !err.Retryable {
+		t.Errorf("expected force_destroy to keep retrying, got %v", err)
+	}
+
+	// an ordinary deleting state keeps polling
+	if err := cfsSnapshotDeleteRetry("snap-test", "deleting", false); err == nil || !err.Retryable {
+		t.Errorf("expected a plain delete to keep retrying, got %v", err)
+	}
+}
+
+func TestCfsSnapshotFileSystemIdValidation(t *testing.T) {
+	validate := validateStringPrefix("cfs-")
+
+	// a snapshot id pasted into the file system field is rejected at plan time
+	if _, errors := validate("snap-iobiaxtj", "file_system_id"); len(errors) == 0 {
+		t.Error("expected a snapshot id to be rejected as `file_system_id`")
+	}
+
+	// a real file system id passes
+	if _, errors := validate("cfs-iobiaxtj", "file_system_id"); len(errors) > 0 {
+		t.Errorf("expected a cfs id to be accepted, got: %v", errors)
+	}
+}
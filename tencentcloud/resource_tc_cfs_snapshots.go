@@ -0,0 +1,193 @@
+/*
+Provides a resource to snapshot a batch of cfs file systems at once
+
+Example Usage
+
+```hcl
+resource "tencentcloud_cfs_snapshots" "backup" {
+  file_system_ids      = ["cfs-iobiaxtj", "cfs-axalx3en"]
+  snapshot_name_prefix = "nightly-"
+}
+```
+*/
+package tencentcloud
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	cfs "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cfs/v20190719"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/ratelimit"
+)
+
+func resourceTencentCloudCfsSnapshots() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceTencentCloudCfsSnapshotsCreate,
+		Read:   resourceTencentCloudCfsSnapshotsRead,
+		Delete: resourceTencentCloudCfsSnapshotsDelete,
+		Schema: map[string]*schema.Schema{
+			"file_system_ids": {
+				Type: schema.TypeSet,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Required:    true,
+				ForceNew:    true,
+				MinItems:    1,
+				Description: "Ids of the file systems to snapshot, one snapshot is created per file system.",
+			},
+
+			"snapshot_name_prefix": {
+				Optional:     true,
+				ForceNew:     true,
+				Type:         schema.TypeString,
+				ValidateFunc: validateCfsSnapshotName,
+				Description:  "Prefix of the snapshot names; each snapshot is named prefix plus its file system id.",
+			},
+
+			"snapshot_ids": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "Map from file system id to the id of the snapshot created for it.",
+			},
+		},
+	}
+}
+
+// cfsBatchSnapshotName derives the snapshot name for one file system of a
+// batch; without a prefix the API default name is kept.
+func cfsBatchSnapshotName(prefix, fileSystemId string) string {
+	if prefix == "" {
+		return ""
+	}
+	return prefix + fileSystemId
+}
+
+func resourceTencentCloudCfsSnapshotsCreate(d *schema.ResourceData, meta interface{}) error {
+	defer logElapsed("resource.tencentcloud_cfs_snapshots.create")()
+	defer inconsistentCheck(d, meta)()
+
+	logId := getLogId(contextNil)
+
+	fileSystemIds := helper.InterfacesStrings(d.Get("file_system_ids").(*schema.Set).List())
+	sort.Strings(fileSystemIds)
+	prefix := d.Get("snapshot_name_prefix").(string)
+
+	service := CfsService{client: meta.(*TencentCloudClient).apiV3Conn}
+	snapshotIds := make(map[string]interface{}, len(fileSystemIds))
+
+	//the id only depends on the requested file systems, so it is set before
+	//the first create; a failure partway through the loop then leaves the
+	//already created snapshots tracked in state instead of leaking them
+	d.SetId(helper.DataResourceIdsHash(fileSystemIds))
+
+	for _, fileSystemId := range fileSystemIds {
+		request := cfs.NewCreateCfsSnapshotRequest()
+		response := cfs.NewCreateCfsSnapshotResponse()
+		request.FileSystemId = helper.String(fileSystemId)
+		if name := cfsBatchSnapshotName(prefix, fileSystemId); name != "" {
+			request.SnapshotName = helper.String(name)
+		}
+
+		err := resource.Retry(writeRetryTimeout, func() *resource.RetryError {
+			ratelimit.Check(request.GetAction())
+			result, e := meta.(*TencentCloudClient).apiV3Conn.UseCfsClient().CreateCfsSnapshot(request)
+			if e != nil {
+				//a file system busy with another operation can take a snapshot once it finishes
+				return retryError(e, CfsInvalidFsStatus)
+			} else {
+				log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n", logId, request.GetAction(), request.ToJsonString(), result.ToJsonString())
+			}
+			response = result
+			return nil
+		})
+		if err != nil {
+			log.Printf("[CRITAL]%s create cfs snapshot for %s failed, reason:%+v", logId, fileSystemId, err)
+			return err
+		}
+
+		snapshotId := *response.Response.SnapshotId
+		snapshotIds[fileSystemId] = snapshotId
+		//persist the partial map before waiting, a waiter failure must not
+		//lose the snapshot that was just created
+		_ = d.Set("snapshot_ids", snapshotIds)
+
+		conf := BuildStateChangeConfWithBackoff([]string{}, []string{"available"}, 2*readRetryTimeout, time.Second, service.CfsSnapshotStateRefreshFunc(snapshotId, []string{}))
+		if _, e := conf.WaitForState(); e != nil {
+			return e
+		}
+	}
+
+	return resourceTencentCloudCfsSnapshotsRead(d, meta)
+}
+
+func resourceTencentCloudCfsSnapshotsRead(d *schema.ResourceData, meta interface{}) error {
+	defer logElapsed("resource.tencentcloud_cfs_snapshots.read")()
+	defer inconsistentCheck(d, meta)()
+
+	logId := getLogId(contextNil)
+	ctx := context.WithValue(context.TODO(), logIdKey, logId)
+
+	service := CfsService{client: meta.(*TencentCloudClient).apiV3Conn}
+
+	snapshotIds := d.Get("snapshot_ids").(map[string]interface{})
+	remaining := make(map[string]interface{}, len(snapshotIds))
+	for fileSystemId, snapshotId := range snapshotIds {
+		snapshot, err := service.DescribeCfsSnapshotById(ctx, snapshotId.(string))
+		if err != nil {
+			return err
+		}
+		if snapshot == nil {
+			log.Printf("[WARN]%s snapshot %s of file system %s not found, please check if it has been deleted.\n", logId, snapshotId, fileSystemId)
+			continue
+		}
+		remaining[fileSystemId] = snapshotId
+	}
+
+	if len(remaining) == 0 {
+		d.SetId("")
+		return nil
+	}
+	_ = d.Set("snapshot_ids", remaining)
+
+	return nil
+}
+
+func resourceTencentCloudCfsSnapshotsDelete(d *schema.ResourceData, meta interface{}) error {
+	defer logElapsed("resource.tencentcloud_cfs_snapshots.delete")()
+	defer inconsistentCheck(d, meta)()
+
+	logId := getLogId(contextNil)
+	ctx := context.WithValue(context.TODO(), logIdKey, logId)
+
+	service := CfsService{client: meta.(*TencentCloudClient).apiV3Conn}
+
+	for fileSystemId, snapshotId := range d.Get("snapshot_ids").(map[string]interface{}) {
+		id := snapshotId.(string)
+		if err := service.DeleteCfsSnapshotById(ctx, id); err != nil {
+			return err
+		}
+
+		err := resource.Retry(2*readRetryTimeout, func() *resource.RetryError {
+			instance, errRet := service.DescribeCfsSnapshotById(ctx, id)
+			if errRet != nil {
+				return retryError(errRet, InternalError)
+			}
+			if instance == nil {
+				return nil
+			}
+			return resource.RetryableError(fmt.Errorf("cfs snapshot %s of file system %s status is %s, retry...", id, fileSystemId, *instance.Status))
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package tencentcloud
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestCfsBatchSnapshotName(t *testing.T) {
+	if got := cfsBatchSnapshotName("nightly-", "cfs-iobiaxtj"); got != "nightly-cfs-iobiaxtj" {
+		t.Errorf("unexpected snapshot name: %s", got)
+	}
+	// without a prefix the API default name is kept
+	if got := cfsBatchSnapshotName("", "cfs-iobiaxtj"); got != "" {
+		t.Errorf("expected empty name without a prefix, got %s", got)
+	}
+}
+
+func TestAccTencentCloudCfsSnapshotsResource_basic(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCfsSnapshots,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("tencentcloud_cfs_snapshots.backup", "id"),
+					resource.TestCheckResourceAttr("tencentcloud_cfs_snapshots.backup", "file_system_ids.#", "2"),
+					resource.TestCheckResourceAttr("tencentcloud_cfs_snapshots.backup", "snapshot_ids.%", "2"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCfsSnapshots = `
+
+resource "tencentcloud_cfs_snapshots" "backup" {
+  file_system_ids      = ["cfs-iobiaxtj", "cfs-axalx3en"]
+  snapshot_name_prefix = "tf-batch-"
+}
+
+`
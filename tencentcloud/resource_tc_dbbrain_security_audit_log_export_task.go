@@ -21,7 +21,9 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
@@ -34,9 +36,14 @@ func resourceTencentCloudDbbrainSecurityAuditLogExportTask() *schema.Resource {
 		Read:   resourceTencentCloudDbbrainSecurityAuditLogExportTaskRead,
 		Create: resourceTencentCloudDbbrainSecurityAuditLogExportTaskCreate,
 		Delete: resourceTencentCloudDbbrainSecurityAuditLogExportTaskDelete,
-		// Importer: &schema.ResourceImporter{
-		// 	State: schema.ImportStatePassthrough,
-		// },
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		CustomizeDiff: func(d *schema.ResourceDiff, meta interface{}) error {
+			//reject product/group/feature combinations the API would refuse at plan time
+			hasDangerLevels := d.Get("danger_levels").(*schema.Set).Len() > 0
+			return checkDbbrainSecurityAuditTaskArgs(d.Get("product").(string), d.Get("sec_audit_group_id").(string), hasDangerLevels)
+		},
 		Schema: map[string]*schema.Schema{
 			"sec_audit_group_id": {
 				Type:        schema.TypeString,
@@ -63,28 +70,81 @@ func resourceTencentCloudDbbrainSecurityAuditLogExportTask() *schema.Resource {
 				Type:        schema.TypeString,
 				Required:    true,
 				ForceNew:    true,
-				Description: "product, optional value is mysql.",
+				Description: "product, valid values: `mysql`, `cynosdb`.",
 			},
 
 			"danger_levels": {
 				Type: schema.TypeSet,
 				Elem: &schema.Schema{
-					Type: schema.TypeInt,
+					Type:         schema.TypeInt,
+					ValidateFunc: validateAllowedIntValue([]int{0, 1, 2, 3}),
 				},
 				Optional:    true,
 				ForceNew:    true,
 				Description: "List of log risk levels, supported values include: 0 no risk; 1 low risk; 2 medium risk; 3 high risk.",
 			},
 
+			"wait_for_completion": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Whether the apply waits until the export task reaches a terminal state. Default is `false` to avoid long applies on large exports.",
+			},
+
 			"async_request_id": {
 				Type:        schema.TypeInt,
 				Computed:    true,
 				Description: "request of async id.",
 			},
+
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "status of the export task.",
+			},
+
+			"progress": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "execution progress of the export task.",
+			},
+
+			"total_size": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "total size of the exported log files, in KB.",
+			},
 		},
 	}
 }
 
+// security audit log export per product: which products accept a security audit
+// group at all and whether tasks of that product may filter by danger level
+var dbbrainSecurityAuditProducts = map[string]bool{
+	"mysql":   true,
+	"cynosdb": false,
+}
+
+var dbbrainSagIdPattern = regexp.MustCompile(`^sag-[0-9a-z]+$`)
+
+// checkDbbrainSecurityAuditTaskArgs rejects product/group/feature combinations
+// the CreateSecurityAuditLogExportTask API would refuse, so users see the
+// problem at plan time instead of at apply.
+func checkDbbrainSecurityAuditTaskArgs(product, secAuditGroupId string, hasDangerLevels bool) error {
+	supportsDangerLevels, ok := dbbrainSecurityAuditProducts[product]
+	if !ok {
+		return fmt.Errorf("product `%s` does not support security audit log export, valid products: mysql, cynosdb", product)
+	}
+	if !dbbrainSagIdPattern.MatchString(secAuditGroupId) {
+		return fmt.Errorf("`sec_audit_group_id` of product `%s` must look like `sag-xxxxxxxx`, got `%s`", product, secAuditGroupId)
+	}
+	if hasDangerLevels && !supportsDangerLevels {
+		return fmt.Errorf("`danger_levels` is not supported for product `%s`", product)
+	}
+	return nil
+}
+
 func resourceTencentCloudDbbrainSecurityAuditLogExportTaskCreate(d *schema.ResourceData, meta interface{}) error {
 	defer logElapsed("resource.tencentcloud_dbbrain_security_audit_log_export_task.create")()
 	defer inconsistentCheck(d, meta)()
@@ -160,9 +220,42 @@ func resourceTencentCloudDbbrainSecurityAuditLogExportTaskCreate(d *schema.Resou
 	}
 
 	d.SetId(secAuditGroupId + FILED_SP + asyncRequestId)
+
+	if d.Get("wait_for_completion").(bool) {
+		err = waitForDbbrainExportTaskCompletion(3*readRetryTimeout, func() (*dbbrain.SecLogExportTaskInfo, error) {
+			return service.DescribeDbbrainSecurityAuditLogExportTask(ctx, helper.String(secAuditGroupId), helper.String(asyncRequestId), nil)
+		})
+		if err != nil {
+			log.Printf("[CRITAL]%s wait for dbbrain securityAuditLogExportTask completion failed, reason:%+v", logId, err)
+			return err
+		}
+	}
+
 	return resourceTencentCloudDbbrainSecurityAuditLogExportTaskRead(d, meta)
 }
 
+// waitForDbbrainExportTaskCompletion polls the export task through describe
+// until it reaches a terminal state, failing for a task that disappears or
+// ends in a failed state instead of finishing.
+func waitForDbbrainExportTaskCompletion(timeout time.Duration, describe func() (*dbbrain.SecLogExportTaskInfo, error)) error {
+	return resource.Retry(timeout, func() *resource.RetryError {
+		task, err := describe()
+		if err != nil {
+			return retryError(err)
+		}
+		if task == nil || task.Status == nil {
+			return resource.NonRetryableError(errors.New("security audit log export task disappeared while waiting for completion"))
+		}
+		switch strings.ToLower(*task.Status) {
+		case "finished", "success":
+			return nil
+		case "failed", "canceled", "cancelled":
+			return resource.NonRetryableError(fmt.Errorf("security audit log export task ended in state `%s`", *task.Status))
+		}
+		return resource.RetryableError(fmt.Errorf("security audit log export task is still `%s`, retry...", *task.Status))
+	})
+}
+
 func resourceTencentCloudDbbrainSecurityAuditLogExportTaskRead(d *schema.ResourceData, meta interface{}) error {
 	defer logElapsed("resource.tencentcloud_dbbrain_security_audit_log_export_task.read")()
 	defer inconsistentCheck(d, meta)()
@@ -184,13 +277,13 @@ func resourceTencentCloudDbbrainSecurityAuditLogExportTaskRead(d *schema.Resourc
 		return err
 	}
 
-	// _ = d.Set("sec_audit_group_id", secAuditGroupId)
-
 	if securityAuditLogExportTask == nil {
 		d.SetId("")
 		return fmt.Errorf("resource `securityAuditLogExportTask` %s does not exist", d.Id())
 	}
 
+	_ = d.Set("sec_audit_group_id", secAuditGroupId)
+
 	if securityAuditLogExportTask.LogStartTime != nil {
 		_ = d.Set("start_time", securityAuditLogExportTask.LogStartTime)
 	}
@@ -200,13 +293,25 @@ func resourceTencentCloudDbbrainSecurityAuditLogExportTaskRead(d *schema.Resourc
 	}
 
 	if securityAuditLogExportTask.DangerLevels != nil {
-		_ = d.Set("danger_levels", securityAuditLogExportTask.DangerLevels)
+		_ = d.Set("danger_levels", helper.Uint64sInterfaces(securityAuditLogExportTask.DangerLevels))
 	}
 
 	if securityAuditLogExportTask.AsyncRequestId != nil {
 		_ = d.Set("async_request_id", securityAuditLogExportTask.AsyncRequestId)
 	}
 
+	if securityAuditLogExportTask.Status != nil {
+		_ = d.Set("status", securityAuditLogExportTask.Status)
+	}
+
+	if securityAuditLogExportTask.Progress != nil {
+		_ = d.Set("progress", securityAuditLogExportTask.Progress)
+	}
+
+	if securityAuditLogExportTask.TotalSize != nil {
+		_ = d.Set("total_size", securityAuditLogExportTask.TotalSize)
+	}
+
 	return nil
 }
 
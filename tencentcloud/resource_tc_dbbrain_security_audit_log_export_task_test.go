@@ -8,7 +8,9 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	dbbrain "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/dbbrain/v20210527"
 	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
 )
 
@@ -55,6 +57,25 @@ func testSweepDbbrainSecurityAuditLogExportTask(r string) error {
 	return nil
 }
 
+func TestCheckDbbrainSecurityAuditTaskArgs(t *testing.T) {
+	// a valid product/group combo passes
+	if err := checkDbbrainSecurityAuditTaskArgs("mysql", defaultDbBrainsagId, true); err != nil {
+		t.Fatalf("expected mysql with danger levels to be valid, got: %v", err)
+	}
+
+	// unknown product, malformed group id and unsupported danger levels are
+	// rejected before reaching the API
+	if err := checkDbbrainSecurityAuditTaskArgs("postgres", defaultDbBrainsagId, false); err == nil {
+		t.Fatalf("expected an unknown product to be rejected")
+	}
+	if err := checkDbbrainSecurityAuditTaskArgs("mysql", "not-a-group-id", false); err == nil {
+		t.Fatalf("expected a malformed sec_audit_group_id to be rejected")
+	}
+	if err := checkDbbrainSecurityAuditTaskArgs("cynosdb", defaultDbBrainsagId, true); err == nil {
+		t.Fatalf("expected danger_levels on cynosdb to be rejected")
+	}
+}
+
 func TestAccTencentCloudDbbrainSecurityAuditLogExportTaskResource_basic(t *testing.T) {
 	t.Parallel()
 	loc, _ := time.LoadLocation("Asia/Chongqing")
@@ -78,6 +99,13 @@ func TestAccTencentCloudDbbrainSecurityAuditLogExportTaskResource_basic(t *testi
 					resource.TestCheckResourceAttr("tencentcloud_dbbrain_security_audit_log_export_task.task", "danger_levels.#", "3"),
 				),
 			},
+			{
+				ResourceName:      "tencentcloud_dbbrain_security_audit_log_export_task.task",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// product is not returned by DescribeSecurityAuditLogExportTasks
+				ImportStateVerifyIgnore: []string{"product"},
+			},
 		},
 	})
 }
@@ -157,3 +185,55 @@ resource "tencentcloud_dbbrain_security_audit_log_export_task" "task" {
 
 `, defaultDbBrainsagId, st, et)
 }
+
+func TestDbbrainSecurityAuditLogExportTaskDangerLevelsValidate(t *testing.T) {
+	validateFunc := resourceTencentCloudDbbrainSecurityAuditLogExportTask().Schema["danger_levels"].Elem.(*schema.Schema).ValidateFunc
+
+	for _, level := range []int{0, 1, 2, 3} {
+		if _, errs := validateFunc(level, "danger_levels"); len(errs) > 0 {
+			t.Errorf("danger level %d should be accepted, got: %v", level, errs)
+		}
+	}
+	if _, errs := validateFunc(5, "danger_levels"); len(errs) == 0 {
+		t.Errorf("danger level 5 should be rejected at plan time")
+	}
+}
+
+func TestWaitForDbbrainExportTaskCompletion(t *testing.T) {
+	task := func(status string) *dbbrain.SecLogExportTaskInfo {
+		return &dbbrain.SecLogExportTaskInfo{Status: helper.String(status)}
+	}
+
+	// the waiter polls a running task through to success
+	statuses := []string{"running", "running", "finished"}
+	polls := 0
+	err := waitForDbbrainExportTaskCompletion(time.Minute, func() (*dbbrain.SecLogExportTaskInfo, error) {
+		current := task(statuses[polls])
+		if polls < len(statuses)-1 {
+			polls++
+		}
+		return current, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error waiting through to success: %v", err)
+	}
+	if polls != len(statuses)-1 {
+		t.Errorf("expected %d retries before success, got %d", len(statuses)-1, polls)
+	}
+
+	// a failed task stops the wait and names the state
+	err = waitForDbbrainExportTaskCompletion(time.Minute, func() (*dbbrain.SecLogExportTaskInfo, error) {
+		return task("failed"), nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "failed") {
+		t.Errorf("expected an error naming the failed state, got %v", err)
+	}
+
+	// a task that disappeared mid-export is not retried
+	err = waitForDbbrainExportTaskCompletion(time.Minute, func() (*dbbrain.SecLogExportTaskInfo, error) {
+		return nil, nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "disappeared") {
+		t.Errorf("expected an error for a missing task, got %v", err)
+	}
+}
@@ -26,9 +26,12 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	sdkErrors "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/errors"
 	vpc "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/vpc/v20170312"
 	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
 	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/ratelimit"
@@ -43,6 +46,30 @@ func resourceTencentCloudEip() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: func(d *schema.ResourceDiff, meta interface{}) error {
+			//bandwidth of a BANDWIDTH_PACKAGE eip is governed by the package,
+			//so a user-set value would otherwise drift forever
+			if d.Get("internet_charge_type").(string) == EIP_INTERNET_CHARGE_TYPE_BANDWIDTH_PACKAGE {
+				if _, ok := d.GetOkExists("internet_max_bandwidth_out"); ok {
+					log.Printf("[WARN] internet_max_bandwidth_out of a BANDWIDTH_PACKAGE eip is governed by the bandwidth package, the configured value is ignored")
+				}
+				return d.Clear("internet_max_bandwidth_out")
+			}
+			//each charge type caps bandwidth differently, reject an over-cap
+			//value at plan time instead of a confusing API rejection
+			if v, ok := d.GetOkExists("internet_max_bandwidth_out"); ok {
+				if err := checkEipMaxBandwidthOut(d.Get("internet_charge_type").(string), v.(int)); err != nil {
+					return err
+				}
+			}
+			//renewal management only exists for monthly prepaid bandwidth
+			if v, ok := d.GetOkExists("auto_renew_flag"); ok && v.(int) != 0 &&
+				d.Get("internet_charge_type").(string) != EIP_INTERNET_CHARGE_TYPE_PREPAID_BY_MONTH {
+				return fmt.Errorf("auto_renew_flag can only be set when internet_charge_type is `%s`",
+					EIP_INTERNET_CHARGE_TYPE_PREPAID_BY_MONTH)
+			}
+			return nil
+		},
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -60,10 +87,11 @@ func resourceTencentCloudEip() *schema.Resource {
 				Description: "The type of eip. Valid value:  `EIP` and `AnycastEIP` and `HighQualityEIP`. Default is `EIP`.",
 			},
 			"anycast_zone": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				ForceNew:    true,
-				Description: "The zone of anycast. Valid value: `ANYCAST_ZONE_GLOBAL` and `ANYCAST_ZONE_OVERSEAS`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateAllowedStringValue(EIP_ANYCAST_ZONE),
+				Description:  "The zone of anycast. Valid value: `ANYCAST_ZONE_GLOBAL` and `ANYCAST_ZONE_OVERSEAS`.",
 			},
 			"applicable_for_clb": {
 				Type:        schema.TypeBool,
@@ -72,10 +100,11 @@ func resourceTencentCloudEip() *schema.Resource {
 				Deprecated:  "It has been deprecated from version 1.27.0.",
 			},
 			"internet_service_provider": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				ForceNew:    true,
-				Description: "Internet service provider of eip. Valid value: `BGP`, `CMCC`, `CTCC` and `CUCC`.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: eipInternetServiceProviderDiffSuppress,
+				Description:      "Internet service provider of eip. Valid value: `BGP`, `CMCC`, `CTCC` and `CUCC`.",
 			},
 			"internet_charge_type": {
 				Type:        schema.TypeString,
@@ -89,6 +118,20 @@ func resourceTencentCloudEip() *schema.Resource {
 				Optional:    true,
 				Description: "The bandwidth limit of EIP, unit is Mbps.",
 			},
+			"prepaid_period": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateAllowedIntValue([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 24, 36}),
+				Description:  "Period of the prepaid EIP, in months. Valid values: `1`-`12`, `24`, `36`, defaults to `1` on creation. Only makes sense when `internet_charge_type` is `BANDWIDTH_PREPAID_BY_MONTH`. Caution: changing this value renews the EIP for the new period.",
+			},
+			"auto_renew_flag": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validateAllowedIntValue([]int{0, 1, 2}),
+				Description:  "Auto renew flag of the prepaid EIP. `0` - manual renewal, `1` - auto renewal, `2` - no renewal on expiration. Only makes sense when `internet_charge_type` is `BANDWIDTH_PREPAID_BY_MONTH`. Can only be changed together with `prepaid_period`, as the API applies the flag only while renewing.",
+			},
 			"tags": {
 				Type:        schema.TypeMap,
 				Optional:    true,
@@ -99,7 +142,23 @@ func resourceTencentCloudEip() *schema.Resource {
 				Optional:    true,
 				Description: "ID of bandwidth package, it will set when `internet_charge_type` is `BANDWIDTH_PACKAGE`.",
 			},
+			"prevent_release": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether destroying the EIP is blocked. Releasing an EIP loses the IP forever; set this on production EIPs so a destroy fails until the flag is turned off. Default is `false`.",
+			},
 			// computed
+			"all_tags": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "The tags observed on the EIP, including those applied outside of Terraform. Unlike `tags`, this attribute is never managed and can be referenced safely.",
+			},
+			"resource_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The fully-qualified QCS resource name of the EIP, which can be used in CAM policies and tag automation.",
+			},
 			"public_ip": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -110,10 +169,111 @@ func resourceTencentCloudEip() *schema.Resource {
 				Computed:    true,
 				Description: "The EIP current status.",
 			},
+			"region": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The region the EIP lives in, useful for multi-region module outputs.",
+			},
 		},
 	}
 }
 
+// waitForEipBandwidthOut polls describe until the reported bandwidth matches
+// the requested value. Traditional account EIPs report no bandwidth at all;
+// those are accepted as-is since there is nothing to verify against.
+func waitForEipBandwidthOut(timeout time.Duration, expected uint64, describe func() (*vpc.Address, error)) error {
+	return resource.Retry(timeout, func() *resource.RetryError {
+		eip, err := describe()
+		if err != nil {
+			return retryError(err)
+		}
+		if eip == nil || eip.Bandwidth == nil {
+			return nil
+		}
+		if *eip.Bandwidth != expected {
+			return resource.RetryableError(fmt.Errorf("eip bandwidth is still %d, waiting for %d", *eip.Bandwidth, expected))
+		}
+		return nil
+	})
+}
+
+// eipMaxBandwidthOut returns the bandwidth cap in Mbps of a charge type; 0
+// means no cap is enforced here (package-governed or unknown types are left
+// to the API)
+func eipMaxBandwidthOut(chargeType string) int {
+	switch chargeType {
+	case "TRAFFIC_POSTPAID_BY_HOUR":
+		return 100
+	case "BANDWIDTH_POSTPAID_BY_HOUR", EIP_INTERNET_CHARGE_TYPE_PREPAID_BY_MONTH:
+		return 1000
+	}
+	return 0
+}
+
+// checkEipMaxBandwidthOut rejects a bandwidth above the charge type's cap
+func checkEipMaxBandwidthOut(chargeType string, bandwidthOut int) error {
+	max := eipMaxBandwidthOut(chargeType)
+	if max > 0 && bandwidthOut > max {
+		return fmt.Errorf("internet_max_bandwidth_out `%d` exceeds the `%d` Mbps cap of internet_charge_type `%s`",
+			bandwidthOut, max, chargeType)
+	}
+	return nil
+}
+
+// checkEipReleaseAllowed blocks the delete of an EIP guarded by
+// `prevent_release`; releasing an EIP loses the IP forever, so the guard is a
+// resource-local counterpart of `prevent_destroy` that automation can toggle.
+func checkEipReleaseAllowed(eipId string, preventRelease bool) error {
+	if preventRelease {
+		return fmt.Errorf("eip %s is protected by `prevent_release`, set it to false before destroying the eip", eipId)
+	}
+	return nil
+}
+
+// eipCreateStateChangeConf waits out CREATING and the transient bind states
+// until the EIP reaches a terminal UNBIND/BIND status.
+func eipCreateStateChangeConf(refresh resource.StateRefreshFunc) *resource.StateChangeConf {
+	return BuildStateChangeConf(
+		[]string{EIP_STATUS_CREATING, EIP_STATUS_BINDING, EIP_STATUS_UNBINDING},
+		[]string{EIP_STATUS_UNBIND, EIP_STATUS_BIND, EIP_STATUS_BIND_ENI},
+		2*readRetryTimeout, time.Second, refresh)
+}
+
+// eipPrepaidChargeParams builds the prepaid charge parameters of an EIP;
+// non-prepaid charge types carry none. An unset period falls back to one
+// month, the attribute is computed so states may carry no value.
+func eipPrepaidChargeParams(chargeType string, period, autoRenewFlag int) *vpc.AddressChargePrepaid {
+	if chargeType != EIP_INTERNET_CHARGE_TYPE_PREPAID_BY_MONTH {
+		return nil
+	}
+	if period <= 0 {
+		period = 1
+	}
+	return &vpc.AddressChargePrepaid{
+		Period:        helper.IntInt64(period),
+		AutoRenewFlag: helper.IntInt64(autoRenewFlag),
+	}
+}
+
+// eipTagsViews splits the observed API tags into the managed view written to
+// `tags` (key casing normalized against the configuration, so a configured
+// empty map still manages the resource to empty) and the observed view
+// written to `all_tags`, which always mirrors the API verbatim.
+func eipTagsViews(apiTags, configured map[string]string) (managed, observed map[string]string) {
+	observed = make(map[string]string, len(apiTags))
+	for k, v := range apiTags {
+		observed[k] = v
+	}
+	managed = normalizeResourceTagsCase(apiTags, configured)
+	return
+}
+
+// the API normalizes the ISP casing (e.g. `bgp` is stored as `BGP`), a
+// case-only difference must not recreate the EIP
+func eipInternetServiceProviderDiffSuppress(k, old, new string, d *schema.ResourceData) bool {
+	return strings.EqualFold(old, new)
+}
+
 func resourceTencentCloudEipCreate(d *schema.ResourceData, meta interface{}) error {
 	defer logElapsed("resource.tencentcloud_eip.create")()
 
@@ -137,6 +297,8 @@ func resourceTencentCloudEipCreate(d *schema.ResourceData, meta interface{}) err
 	}
 	if v, ok := d.GetOk("internet_charge_type"); ok {
 		request.InternetChargeType = helper.String(v.(string))
+		request.AddressChargePrepaid = eipPrepaidChargeParams(v.(string),
+			d.Get("prepaid_period").(int), d.Get("auto_renew_flag").(int))
 	}
 	if v, ok := d.GetOk("internet_max_bandwidth_out"); ok {
 		request.InternetMaxBandwidthOut = helper.IntInt64(v.(int))
@@ -185,18 +347,10 @@ func resourceTencentCloudEipCreate(d *schema.ResourceData, meta interface{}) err
 		}
 	}
 
-	// wait for status
-	err = resource.Retry(readRetryTimeout, func() *resource.RetryError {
-		eip, errRet := vpcService.DescribeEipById(ctx, eipId)
-		if errRet != nil {
-			return retryError(errRet)
-		}
-		if eip != nil && *eip.AddressStatus == EIP_STATUS_CREATING {
-			return resource.RetryableError(fmt.Errorf("eip is still creating"))
-		}
-		return nil
-	})
-	if err != nil {
+	// wait until the EIP settles in a terminal state; intermediate states like
+	// BINDING would otherwise let downstream associations race
+	conf := eipCreateStateChangeConf(vpcService.EipStateRefreshFunc(eipId, []string{}))
+	if _, err := conf.WaitForState(); err != nil {
 		return err
 	}
 
@@ -231,10 +385,10 @@ func resourceTencentCloudEipRead(d *schema.ResourceData, meta interface{}) error
 
 	eipId := d.Id()
 	var eip *vpc.Address
-	err := resource.Retry(readRetryTimeout, func() *resource.RetryError {
+	err := helper.RetryDescribe(ctx, readRetryTimeout, func() error {
 		instance, errRet := vpcService.DescribeEipById(ctx, eipId)
 		if errRet != nil {
-			return retryError(errRet)
+			return errRet
 		}
 		eip = instance
 		return nil
@@ -255,15 +409,31 @@ func resourceTencentCloudEipRead(d *schema.ResourceData, meta interface{}) error
 
 	bgp, err := vpcService.DescribeVpcBandwidthPackageByEip(ctx, eipId)
 	if err != nil {
-		log.Printf("[CRITAL]%s describe eip tags failed: %+v", logId, err)
-		return err
+		// an EIP outside any bandwidth package must still be readable
+		if sdkErr, ok := err.(*sdkErrors.TencentCloudSDKError); ok && sdkErr.Code == VPCNotFound {
+			log.Printf("[WARN]%s eip %s is not in any bandwidth package: %+v", logId, eipId, err)
+		} else {
+			log.Printf("[CRITAL]%s describe eip bandwidth package failed: %+v", logId, err)
+			return err
+		}
 	}
 	_ = d.Set("name", eip.AddressName)
 	_ = d.Set("type", eip.AddressType)
 	_ = d.Set("public_ip", eip.AddressIp)
 	_ = d.Set("status", eip.AddressStatus)
 	_ = d.Set("internet_charge_type", eip.InternetChargeType)
-	_ = d.Set("tags", tags)
+	managedTags, allTags := eipTagsViews(tags, helper.GetTags(d, "tags"))
+	_ = d.Set("tags", managedTags)
+	_ = d.Set("all_tags", allTags)
+	//the exported name carries the account uin, matching the form CAM
+	//policies reference; leave it untouched when the uin cannot be resolved
+	camService := CamService{client: client}
+	if uin, uinErr := camService.DescribeAccountUin(ctx); uinErr != nil {
+		log.Printf("[WARN]%s describe account uin failed, resource_name is not refreshed: %+v", logId, uinErr)
+	} else {
+		_ = d.Set("resource_name", BuildTagResourceNameWithUin(VPC_SERVICE_TYPE, EIP_RESOURCE_TYPE, region, eipId, uin))
+	}
+	_ = d.Set("region", region)
 	if bgp != nil {
 		_ = d.Set("bandwidth_package_id", bgp.BandwidthPackageId)
 	}
@@ -311,10 +481,51 @@ func resourceTencentCloudEipUpdate(d *schema.ResourceData, meta interface{}) err
 			if err != nil {
 				return err
 			}
+			// the change propagates asynchronously; a refresh right after the
+			// update would otherwise report the old value as drift
+			err = waitForEipBandwidthOut(readRetryTimeout, uint64(bandwidthOut), func() (*vpc.Address, error) {
+				return vpcService.DescribeEipById(ctx, eipId)
+			})
+			if err != nil {
+				return err
+			}
 			d.SetPartial("internet_max_bandwidth_out")
 		}
 	}
 
+	//the API applies auto_renew_flag only as part of RenewAddresses, which
+	//bills a renewal; there is no flag-only update for addresses, so reject a
+	//lone flag change instead of silently charging the account
+	if d.HasChange("auto_renew_flag") && !d.HasChange("prepaid_period") {
+		return fmt.Errorf("auto_renew_flag of eip %s can only be changed together with prepaid_period, the API applies the flag only while renewing", eipId)
+	}
+
+	//changing prepaid_period renews the EIP for the new period, like the VPN
+	//gateway, carrying the configured auto_renew_flag along
+	if d.HasChange("prepaid_period") {
+		chargeType := d.Get("internet_charge_type").(string)
+		if err := checkChargeTypeOperation("prepaid_period change", chargeType, EIP_INTERNET_CHARGE_TYPE_PREPAID_BY_MONTH); err != nil {
+			return err
+		}
+		request := vpc.NewRenewAddressesRequest()
+		request.AddressIds = []*string{&eipId}
+		request.AddressChargePrepaid = eipPrepaidChargeParams(chargeType,
+			d.Get("prepaid_period").(int), d.Get("auto_renew_flag").(int))
+		err := resource.Retry(writeRetryTimeout, func() *resource.RetryError {
+			ratelimit.Check(request.GetAction())
+			if _, e := client.UseVpcClient().RenewAddresses(request); e != nil {
+				return retryError(e)
+			}
+			return nil
+		})
+		if err != nil {
+			log.Printf("[CRITAL]%s renew eip failed: %+v", logId, err)
+			return err
+		}
+		d.SetPartial("auto_renew_flag")
+		d.SetPartial("prepaid_period")
+	}
+
 	if d.HasChange("tags") {
 		oldTags, newTags := d.GetChange("tags")
 		replaceTags, deleteTags := diffTags(oldTags.(map[string]interface{}), newTags.(map[string]interface{}))
@@ -341,6 +552,9 @@ func resourceTencentCloudEipDelete(d *schema.ResourceData, meta interface{}) err
 		client: meta.(*TencentCloudClient).apiV3Conn,
 	}
 	eipId := d.Id()
+	if err := checkEipReleaseAllowed(eipId, d.Get("prevent_release").(bool)); err != nil {
+		return err
+	}
 	err := resource.Retry(writeRetryTimeout, func() *resource.RetryError {
 		errRet := vpcService.UnattachEip(ctx, eipId)
 		if errRet != nil {
@@ -352,6 +566,17 @@ func resourceTencentCloudEipDelete(d *schema.ResourceData, meta interface{}) err
 		return err
 	}
 
+	//an EIP can back several bindings at once; deleting against a remaining
+	//one would only time out, so name the blockers instead
+	remaining, err := vpcService.DescribeEipAssociations(ctx, eipId)
+	if err != nil {
+		return err
+	}
+	if len(remaining) > 0 {
+		return fmt.Errorf("eip %s still has bindings that could not be cleared: %s, remove them before destroying the eip",
+			eipId, strings.Join(remaining, ", "))
+	}
+
 	err = resource.Retry(writeRetryTimeout, func() *resource.RetryError {
 		errRet := vpcService.DeleteEip(ctx, eipId)
 		if errRet != nil {
@@ -11,6 +11,8 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	sdkErrors "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/errors"
+	vpc "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/vpc/v20170312"
 )
 
 func init() {
@@ -80,6 +82,10 @@ func TestAccTencentCloudEip_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("tencentcloud_eip.foo", "name", "gateway_eip"),
 					resource.TestCheckResourceAttr("tencentcloud_eip.foo", "status", "UNBIND"),
 					resource.TestCheckResourceAttrSet("tencentcloud_eip.foo", "public_ip"),
+					// a plain EIP outside any bandwidth package must read cleanly
+					resource.TestCheckResourceAttr("tencentcloud_eip.foo", "bandwidth_package_id", ""),
+					// the EIP self-reports the provider region
+					resource.TestCheckResourceAttr("tencentcloud_eip.foo", "region", defaultRegion),
 				),
 			},
 			{
@@ -96,6 +102,7 @@ func TestAccTencentCloudEip_basic(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckEipExists("tencentcloud_eip.foo"),
 					resource.TestCheckResourceAttr("tencentcloud_eip.foo", "tags.test", "test"),
+					resource.TestCheckResourceAttr("tencentcloud_eip.foo", "all_tags.test", "test"),
 					resource.TestCheckResourceAttr("tencentcloud_eip.foo", "status", "UNBIND"),
 					resource.TestCheckResourceAttrSet("tencentcloud_eip.foo", "public_ip"),
 				),
@@ -106,6 +113,7 @@ func TestAccTencentCloudEip_basic(t *testing.T) {
 					testAccCheckEipExists("tencentcloud_eip.foo"),
 					resource.TestCheckNoResourceAttr("tencentcloud_eip.foo", "tags.test"),
 					resource.TestCheckResourceAttr("tencentcloud_eip.foo", "tags.abc", "abc"),
+					resource.TestCheckResourceAttr("tencentcloud_eip.foo", "all_tags.abc", "abc"),
 					resource.TestCheckResourceAttr("tencentcloud_eip.foo", "status", "UNBIND"),
 					resource.TestCheckResourceAttrSet("tencentcloud_eip.foo", "public_ip"),
 				),
@@ -187,6 +195,30 @@ func TestAccTencentCloudEip_bandwidth(t *testing.T) {
 	})
 }
 
+func TestAccTencentCloudEip_bandwidthPackage(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckEipDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEipBandwidthPackage,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckEipExists("tencentcloud_eip.foo"),
+					resource.TestCheckResourceAttr("tencentcloud_eip.foo", "internet_charge_type", "BANDWIDTH_PACKAGE"),
+				),
+			},
+			{
+				// a package-governed bandwidth must not produce a perpetual diff
+				Config:             testAccEipBandwidthPackage,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			},
+		},
+	})
+}
+
 func TestAccTencentCloudEip_chargetype(t *testing.T) {
 	t.Parallel()
 	resource.Test(t, resource.TestCase{
@@ -342,3 +374,238 @@ resource "tencentcloud_eip" "foo" {
 	internet_charge_type = "TRAFFIC_POSTPAID_BY_HOUR"
   }
 `
+
+const testAccEipBandwidthPackage = `
+resource "tencentcloud_eip" "foo" {
+	name = "eip_bandwidth_package"
+	bandwidth_package_id = "bwp-jtvzuky6"
+	internet_charge_type = "BANDWIDTH_PACKAGE"
+	internet_max_bandwidth_out = 10
+  }
+`
+
+func TestEipInternetServiceProviderDiffSuppress(t *testing.T) {
+	// a case-only difference is normalization by the API, not a real change
+	if !eipInternetServiceProviderDiffSuppress("internet_service_provider", "BGP", "bgp", nil) {
+		t.Errorf("expected case-only change of internet_service_provider to be suppressed")
+	}
+	if !eipInternetServiceProviderDiffSuppress("internet_service_provider", "CMCC", "CMCC", nil) {
+		t.Errorf("expected identical internet_service_provider values to be suppressed")
+	}
+	if eipInternetServiceProviderDiffSuppress("internet_service_provider", "BGP", "CMCC", nil) {
+		t.Errorf("expected a real internet_service_provider change to produce a diff")
+	}
+}
+
+func TestIsEipAlreadyUnattachedError(t *testing.T) {
+	for _, code := range []string{EIP_UNATTACH_NOT_PERMIT, EIP_UNATTACH_STATUS_NOT_PERMIT} {
+		err := sdkErrors.NewTencentCloudSDKError(code, "address is not bound", "req-id")
+		if !isEipAlreadyUnattachedError(err) {
+			t.Errorf("code %s should be treated as already unattached", code)
+		}
+	}
+	if isEipAlreadyUnattachedError(sdkErrors.NewTencentCloudSDKError("InternalError", "boom", "req-id")) {
+		t.Errorf("unrelated SDK errors must still fail the unattach")
+	}
+	if isEipAlreadyUnattachedError(fmt.Errorf("plain error")) {
+		t.Errorf("non SDK errors must still fail the unattach")
+	}
+}
+
+func TestWaitForEipBandwidthOut(t *testing.T) {
+	// the new bandwidth only shows up on the third describe
+	reported := []uint64{10, 10, 20}
+	calls := 0
+	err := waitForEipBandwidthOut(time.Minute, 20, func() (*vpc.Address, error) {
+		bandwidth := reported[calls]
+		if calls < len(reported)-1 {
+			calls++
+		}
+		return &vpc.Address{Bandwidth: &bandwidth}, nil
+	})
+	if err != nil {
+		t.Fatalf("delayed propagation should eventually succeed: %v", err)
+	}
+	if calls < 2 {
+		t.Errorf("expected at least 3 describes before the bandwidth matched, got %d", calls+1)
+	}
+
+	// traditional account EIPs report no bandwidth, nothing to verify
+	if err := waitForEipBandwidthOut(time.Minute, 20, func() (*vpc.Address, error) {
+		return &vpc.Address{}, nil
+	}); err != nil {
+		t.Fatalf("missing bandwidth attribute should not block the update: %v", err)
+	}
+}
+
+func TestAccTencentCloudEip_prepaidAutoRenew(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckEipDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEipPrepaidAutoRenew,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckEipExists("tencentcloud_eip.foo"),
+					resource.TestCheckResourceAttr("tencentcloud_eip.foo", "internet_charge_type", "BANDWIDTH_PREPAID_BY_MONTH"),
+					resource.TestCheckResourceAttr("tencentcloud_eip.foo", "auto_renew_flag", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestEipPrepaidChargeParams(t *testing.T) {
+	params := eipPrepaidChargeParams(EIP_INTERNET_CHARGE_TYPE_PREPAID_BY_MONTH, 3, 1)
+	if params == nil || *params.Period != 3 || *params.AutoRenewFlag != 1 {
+		t.Errorf("unexpected prepaid charge params: %+v", params)
+	}
+
+	// non-prepaid charge types carry no prepaid parameters
+	if params := eipPrepaidChargeParams("TRAFFIC_POSTPAID_BY_HOUR", 3, 1); params != nil {
+		t.Errorf("expected no prepaid params for a postpaid eip, got %+v", params)
+	}
+
+	// a state without the computed period falls back to one month
+	params = eipPrepaidChargeParams(EIP_INTERNET_CHARGE_TYPE_PREPAID_BY_MONTH, 0, 1)
+	if params == nil || *params.Period != 1 {
+		t.Errorf("expected an unset period to fall back to 1 month, got %+v", params)
+	}
+}
+
+const testAccEipPrepaidAutoRenew = `
+resource "tencentcloud_eip" "foo" {
+  name                       = "tf-eip-prepaid"
+  internet_charge_type       = "BANDWIDTH_PREPAID_BY_MONTH"
+  internet_max_bandwidth_out = 10
+  prepaid_period             = 1
+  auto_renew_flag            = 1
+}
+`
+
+func TestEipCreateStateChangeConf(t *testing.T) {
+	// a create that walks through an intermediate state must keep waiting
+	// until the EIP reaches a terminal status
+	statuses := []string{EIP_STATUS_CREATING, EIP_STATUS_BINDING, EIP_STATUS_BIND}
+	polls := 0
+	conf := eipCreateStateChangeConf(func() (interface{}, string, error) {
+		status := statuses[polls]
+		if polls < len(statuses)-1 {
+			polls++
+		}
+		obj, state := eipRefreshState(&vpc.Address{AddressStatus: &status})
+		return obj, state, nil
+	})
+	conf.Delay = 10 * time.Millisecond
+	conf.MinTimeout = 10 * time.Millisecond
+	conf.Timeout = 10 * time.Second
+
+	obj, err := conf.WaitForState()
+	if err != nil {
+		t.Fatalf("waiter failed: %v", err)
+	}
+	if polls != len(statuses)-1 {
+		t.Errorf("expected the waiter to poll through the intermediate states, got %d polls", polls)
+	}
+	if eip, ok := obj.(*vpc.Address); !ok || *eip.AddressStatus != EIP_STATUS_BIND {
+		t.Errorf("unexpected terminal object: %+v", obj)
+	}
+
+	// a freshly allocated EIP may not be visible yet; the refresh reports
+	// CREATING instead of an empty state that would abort the waiter
+	if _, status := eipRefreshState(nil); status != EIP_STATUS_CREATING {
+		t.Errorf("expected CREATING for an invisible eip, got %s", status)
+	}
+}
+
+func TestEipTagsViews(t *testing.T) {
+	apiTags := map[string]string{"Env": "prod", "owner": "ops"}
+
+	// only `Env` is managed in the configuration, with different key casing
+	managed, observed := eipTagsViews(apiTags, map[string]string{"env": "prod"})
+
+	// the observed view mirrors the API verbatim, including out-of-band tags
+	if observed["Env"] != "prod" || observed["owner"] != "ops" {
+		t.Errorf("unexpected observed tags: %v", observed)
+	}
+
+	// the managed view normalizes the configured key casing but keeps the
+	// out-of-band tag so an empty configuration still manages to empty
+	if managed["env"] != "prod" || managed["owner"] != "ops" {
+		t.Errorf("unexpected managed tags: %v", managed)
+	}
+	if _, ok := managed["Env"]; ok {
+		t.Errorf("expected managed tags to use the configured key casing, got %v", managed)
+	}
+}
+
+func TestCheckEipMaxBandwidthOut(t *testing.T) {
+	cases := []struct {
+		chargeType string
+		bandwidth  int
+		ok         bool
+	}{
+		{"TRAFFIC_POSTPAID_BY_HOUR", 100, true},
+		{"TRAFFIC_POSTPAID_BY_HOUR", 101, false},
+		{"BANDWIDTH_POSTPAID_BY_HOUR", 1000, true},
+		{"BANDWIDTH_POSTPAID_BY_HOUR", 1001, false},
+		{"BANDWIDTH_PREPAID_BY_MONTH", 2000, false},
+		// package-governed bandwidth is capped by the package, not here
+		{"BANDWIDTH_PACKAGE", 2000, true},
+	}
+	for _, c := range cases {
+		err := checkEipMaxBandwidthOut(c.chargeType, c.bandwidth)
+		if c.ok && err != nil {
+			t.Errorf("%s with %d Mbps: expected accepted, got %v", c.chargeType, c.bandwidth, err)
+		}
+		if !c.ok && err == nil {
+			t.Errorf("%s with %d Mbps: expected rejected", c.chargeType, c.bandwidth)
+		}
+	}
+}
+
+func TestEipAssociations(t *testing.T) {
+	status := EIP_STATUS_BIND
+	instanceId := "nat-3isn9hn0"
+	eniId := "eni-7rop8y4k"
+
+	// an EIP backing two bindings reports both
+	bindings := eipAssociations(&vpc.Address{
+		AddressStatus:      &status,
+		InstanceId:         &instanceId,
+		NetworkInterfaceId: &eniId,
+	})
+	if len(bindings) != 2 {
+		t.Fatalf("expected two bindings, got %v", bindings)
+	}
+	if !strings.Contains(bindings[0], instanceId) || !strings.Contains(bindings[1], eniId) {
+		t.Errorf("expected the bindings to name their resources, got %v", bindings)
+	}
+
+	// an unbound EIP has nothing left to clear
+	unbind := EIP_STATUS_UNBIND
+	if bindings := eipAssociations(&vpc.Address{AddressStatus: &unbind, InstanceId: &instanceId}); len(bindings) != 0 {
+		t.Errorf("expected no bindings for an unbound eip, got %v", bindings)
+	}
+	if bindings := eipAssociations(nil); len(bindings) != 0 {
+		t.Errorf("expected no bindings for a missing eip, got %v", bindings)
+	}
+}
+
+func TestCheckEipReleaseAllowed(t *testing.T) {
+	// a guarded EIP blocks the delete and names the flag to flip
+	err := checkEipReleaseAllowed("eip-test", true)
+	if err == nil {
+		t.Fatal("expected the delete of a guarded eip to be blocked")
+	}
+	if !strings.Contains(err.Error(), "prevent_release") || !strings.Contains(err.Error(), "eip-test") {
+		t.Errorf("expected the error to name the flag and the eip, got: %v", err)
+	}
+
+	// without the guard the delete proceeds
+	if err := checkEipReleaseAllowed("eip-test", false); err != nil {
+		t.Errorf("expected an unguarded eip to be deletable, got: %v", err)
+	}
+}
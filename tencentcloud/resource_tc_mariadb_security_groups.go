@@ -17,6 +17,11 @@ mariadb security_groups can be imported using the id, e.g.
 ```
 $ terraform import tencentcloud_mariadb_security_groups.security_groups tdsql-4pzs5b67#sg-7kpsbxdb#mariadb
 ```
+
+group bindings carry a `group` marker segment in front of the instance group id, e.g.
+```
+$ terraform import tencentcloud_mariadb_security_groups.security_groups group#dbdc-q810131s#sg-7kpsbxdb#mariadb
+```
 */
 package tencentcloud
 
@@ -24,12 +29,22 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	dcdb "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/dcdb/v20180411"
 	mariadb "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/mariadb/v20170312"
 	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/ratelimit"
+)
+
+// both TDSQL products share the security group API family but live on
+// different endpoints, so requests are routed by product
+const (
+	TDSQL_PRODUCT_MARIADB = "mariadb"
+	TDSQL_PRODUCT_DCDB    = "dcdb"
 )
 
 func resourceTencentCloudMariadbSecurityGroups() *schema.Resource {
@@ -43,9 +58,23 @@ func resourceTencentCloudMariadbSecurityGroups() *schema.Resource {
 		},
 		Schema: map[string]*schema.Schema{
 			"instance_id": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "instance id.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"instance_id", "instance_group_id"},
+				Description:  "instance id. Exactly one of `instance_id` and `instance_group_id` must be set.",
+			},
+
+			"instance_group_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"instance_id", "instance_group_id"},
+				Description:  "Dedicated cluster (instance group) id. The security group is bound to every member instance of the group. Only supported when `product` is `mariadb`.",
+			},
+
+			"group_instances": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "Member instances resolved from `instance_group_id`, mapped to whether the security group is currently bound to each.",
 			},
 
 			"security_group_id": {
@@ -54,10 +83,17 @@ func resourceTencentCloudMariadbSecurityGroups() *schema.Resource {
 				Description: "security group id.",
 			},
 
+			"instance_tags": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "tags of the associated instance, informational only.",
+			},
+
 			"product": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "product name, fixed to mariadb.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateAllowedStringValue([]string{TDSQL_PRODUCT_MARIADB, TDSQL_PRODUCT_DCDB}),
+				Description:  "product name. Valid values: `mariadb`, `dcdb`.",
 			},
 		},
 	}
@@ -91,8 +127,108 @@ func resourceTencentCloudMariadbSecurityGroupsCreate(d *schema.ResourceData, met
 		request.Product = helper.String(v.(string))
 	}
 
-	err := resource.Retry(writeRetryTimeout, func() *resource.RetryError {
-		result, e := meta.(*TencentCloudClient).apiV3Conn.UseMariadbClient().AssociateSecurityGroups(request)
+	// validate the pair before calling the API, a missing instance or a security
+	// group from another region only surfaces as an opaque rejection from
+	// AssociateSecurityGroups
+	ctx := context.WithValue(context.TODO(), logIdKey, logId)
+	client := meta.(*TencentCloudClient).apiV3Conn
+	service := MariadbService{client: client}
+	vpcService := VpcService{client: client}
+
+	if v, ok := d.GetOk("instance_group_id"); ok {
+		groupId := v.(string)
+		if product == TDSQL_PRODUCT_DCDB {
+			return fmt.Errorf("`instance_group_id` is only supported when `product` is `%s`", TDSQL_PRODUCT_MARIADB)
+		}
+		members, err := service.DescribeMariadbInstancesByGroup(ctx, groupId)
+		if err != nil {
+			return err
+		}
+		if len(members) == 0 {
+			return fmt.Errorf("instance group `%s` has no member instances in region `%s`", groupId, client.Region)
+		}
+		// the scope check below probes the first member of the group
+		instanceId = members[0]
+		request.InstanceIds = helper.Strings(members)
+
+		instance, err := service.DescribeMariadbDbInstance(ctx, members[0])
+		if err != nil {
+			return err
+		}
+		if instance == nil {
+			return fmt.Errorf("mariadb instance `%s` of group `%s` does not exist in region `%s`", members[0], groupId, client.Region)
+		}
+		sg, err := vpcService.DescribeSecurityGroup(ctx, securityGroupId)
+		if err != nil {
+			return err
+		}
+		if err := checkMariadbSecurityGroupScope(members[0], securityGroupId, sg != nil, client.Region); err != nil {
+			return err
+		}
+
+		err = resource.Retry(writeRetryTimeout, func() *resource.RetryError {
+			ratelimit.Check(request.GetAction())
+			result, e := client.UseMariadbClient().AssociateSecurityGroups(request)
+			if e != nil {
+				return retryError(e)
+			}
+			log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n",
+				logId, request.GetAction(), request.ToJsonString(), result.ToJsonString())
+			return nil
+		})
+		if err != nil {
+			log.Printf("[CRITAL]%s create mariadb securityGroups failed, reason:%+v", logId, err)
+			return err
+		}
+
+		d.SetId(mariadbGroupBindingIdMarker + FILED_SP + groupId + FILED_SP + securityGroupId + FILED_SP + product)
+		return resourceTencentCloudMariadbSecurityGroupsRead(d, meta)
+	}
+
+	if product == TDSQL_PRODUCT_DCDB {
+		dcdbService := DcdbService{client: client}
+		instances, err := dcdbService.DescribeDcdbDbInstance(ctx, instanceId)
+		if err != nil {
+			return err
+		}
+		if instances == nil || len(instances.Instances) == 0 || instances.Instances[0] == nil {
+			return fmt.Errorf("dcdb instance `%s` does not exist in region `%s`", instanceId, client.Region)
+		}
+	} else {
+		instance, err := service.DescribeMariadbDbInstance(ctx, instanceId)
+		if err != nil {
+			return err
+		}
+		if instance == nil {
+			return fmt.Errorf("mariadb instance `%s` does not exist in region `%s`", instanceId, client.Region)
+		}
+	}
+
+	sg, err := vpcService.DescribeSecurityGroup(ctx, securityGroupId)
+	if err != nil {
+		return err
+	}
+	if err := checkMariadbSecurityGroupScope(instanceId, securityGroupId, sg != nil, client.Region); err != nil {
+		return err
+	}
+
+	err = resource.Retry(writeRetryTimeout, func() *resource.RetryError {
+		if product == TDSQL_PRODUCT_DCDB {
+			dcdbRequest := dcdb.NewAssociateSecurityGroupsRequest()
+			dcdbRequest.Product = &product
+			dcdbRequest.SecurityGroupId = &securityGroupId
+			dcdbRequest.InstanceIds = []*string{&instanceId}
+			ratelimit.Check(dcdbRequest.GetAction())
+			result, e := client.UseDcdbClient().AssociateSecurityGroups(dcdbRequest)
+			if e != nil {
+				return retryError(e)
+			}
+			log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n",
+				logId, dcdbRequest.GetAction(), dcdbRequest.ToJsonString(), result.ToJsonString())
+			return nil
+		}
+		ratelimit.Check(request.GetAction())
+		result, e := client.UseMariadbClient().AssociateSecurityGroups(request)
 		if e != nil {
 			return retryError(e)
 		} else {
@@ -111,6 +247,38 @@ func resourceTencentCloudMariadbSecurityGroupsCreate(d *schema.ResourceData, met
 	return resourceTencentCloudMariadbSecurityGroupsRead(d, meta)
 }
 
+// checkMariadbSecurityGroupScope verifies the security group exists in the
+// instance's region before association; a group that cannot be found here lives
+// in another region or account and the association is doomed to fail. Security
+// groups are region scoped and the SDK exposes no VPC on them, so no VPC
+// comparison is possible here.
+func checkMariadbSecurityGroupScope(instanceId, securityGroupId string, sgVisible bool, region string) error {
+	if !sgVisible {
+		return fmt.Errorf("security group `%s` is not found in region `%s` of mariadb instance `%s`, it must exist in the same region",
+			securityGroupId, region, instanceId)
+	}
+	return nil
+}
+
+// mariadbGroupBindingIdMarker is the leading segment of a group-binding
+// composite id; the id alone must carry the group-vs-instance decision so an
+// imported resource, whose attributes are still empty, takes the right path.
+const mariadbGroupBindingIdMarker = "group"
+
+// parseMariadbSecurityGroupsId splits a composite id into its parts; exactly
+// one of instanceId and groupId is non-empty depending on the id form.
+func parseMariadbSecurityGroupsId(id string) (instanceId, groupId, securityGroupId, product string, err error) {
+	idSplit := strings.Split(id, FILED_SP)
+	switch {
+	case len(idSplit) == 3:
+		return idSplit[0], "", idSplit[1], idSplit[2], nil
+	case len(idSplit) == 4 && idSplit[0] == mariadbGroupBindingIdMarker:
+		return "", idSplit[1], idSplit[2], idSplit[3], nil
+	}
+	err = fmt.Errorf("id is broken,%s", id)
+	return
+}
+
 func resourceTencentCloudMariadbSecurityGroupsRead(d *schema.ResourceData, meta interface{}) error {
 	defer logElapsed("resource.tencentcloud_mariadb_security_groups.read")()
 	defer inconsistentCheck(d, meta)()
@@ -120,32 +288,115 @@ func resourceTencentCloudMariadbSecurityGroupsRead(d *schema.ResourceData, meta
 
 	service := MariadbService{client: meta.(*TencentCloudClient).apiV3Conn}
 
-	idSplit := strings.Split(d.Id(), FILED_SP)
-	if len(idSplit) != 3 {
-		return fmt.Errorf("id is broken,%s", d.Id())
+	instanceId, groupId, securityGroupId, product, err := parseMariadbSecurityGroupsId(d.Id())
+	if err != nil {
+		return err
 	}
-	instanceId := idSplit[0]
-	securityGroupId := idSplit[1]
-	product := idSplit[2]
 
-	securityGroup, err := service.DescribeMariadbSecurityGroup(ctx, instanceId, securityGroupId, product)
+	if groupId != "" {
+		members, err := service.DescribeMariadbInstancesByGroup(ctx, groupId)
+		if err != nil {
+			return err
+		}
+		bindings, anyBound, err := mariadbGroupBindings(members, func(memberId string) (bool, error) {
+			securityGroup, err := service.DescribeMariadbSecurityGroup(ctx, memberId, securityGroupId, product)
+			if err != nil {
+				return false, err
+			}
+			return securityGroup != nil, nil
+		})
+		if err != nil {
+			return err
+		}
+		if !anyBound {
+			d.SetId("")
+			log.Printf("[WARN]%s resource `MariadbSecurityGroups` [%s] not found, please check if it has been deleted.\n", logId, d.Id())
+			return nil
+		}
+		_ = d.Set("instance_group_id", groupId)
+		_ = d.Set("security_group_id", securityGroupId)
+		_ = d.Set("product", product)
+		_ = d.Set("group_instances", bindings)
+		return nil
+	}
 
-	if err != nil {
-		return err
+	var found bool
+	if product == TDSQL_PRODUCT_DCDB {
+		dcdbService := DcdbService{client: meta.(*TencentCloudClient).apiV3Conn}
+		groups, err := dcdbService.DescribeDcdbSecurityGroup(ctx, instanceId)
+		if err != nil {
+			return err
+		}
+		if groups != nil {
+			for _, group := range groups.Groups {
+				if group.SecurityGroupId != nil && *group.SecurityGroupId == securityGroupId {
+					found = true
+					break
+				}
+			}
+		}
+	} else {
+		securityGroup, err := service.DescribeMariadbSecurityGroup(ctx, instanceId, securityGroupId, product)
+		if err != nil {
+			return err
+		}
+		found = securityGroup != nil
 	}
 
-	if securityGroup == nil {
+	if !found {
 		d.SetId("")
-		return fmt.Errorf("resource `securityGroups` %s does not exist", securityGroupId)
+		log.Printf("[WARN]%s resource `MariadbSecurityGroups` [%s] not found, please check if it has been deleted.\n", logId, d.Id())
+		return nil
 	}
 
 	_ = d.Set("instance_id", instanceId)
 	_ = d.Set("security_group_id", securityGroupId)
 	_ = d.Set("product", product)
 
+	if product == TDSQL_PRODUCT_MARIADB {
+		instance, err := service.DescribeMariadbDbInstance(ctx, instanceId)
+		if err != nil {
+			return err
+		}
+		if instance != nil {
+			_ = d.Set("instance_tags", flattenMariadbResourceTags(instance.ResourceTags))
+		}
+	}
+
 	return nil
 }
 
+// mariadbGroupBindings resolves which member instances of a group currently
+// carry the security group. It reports the per-instance map tracked in state
+// and whether any binding remains.
+func mariadbGroupBindings(members []string, isBound func(instanceId string) (bool, error)) (map[string]string, bool, error) {
+	bindings := make(map[string]string, len(members))
+	anyBound := false
+	for _, member := range members {
+		bound, err := isBound(member)
+		if err != nil {
+			return nil, false, err
+		}
+		bindings[member] = strconv.FormatBool(bound)
+		if bound {
+			anyBound = true
+		}
+	}
+	return bindings, anyBound, nil
+}
+
+// flattenMariadbResourceTags converts the instance's resource tags into the
+// map form used by schema.TypeMap attributes.
+func flattenMariadbResourceTags(resourceTags []*mariadb.ResourceTag) map[string]string {
+	tags := make(map[string]string, len(resourceTags))
+	for _, resourceTag := range resourceTags {
+		if resourceTag.TagKey != nil && resourceTag.TagValue != nil {
+			tags[*resourceTag.TagKey] = *resourceTag.TagValue
+		}
+	}
+	return tags
+}
+
 func resourceTencentCloudMariadbSecurityGroupsUpdate(d *schema.ResourceData, meta interface{}) error {
 	defer logElapsed("resource.tencentcloud_mariadb_security_groups.update")()
 	defer inconsistentCheck(d, meta)()
@@ -154,13 +405,10 @@ func resourceTencentCloudMariadbSecurityGroupsUpdate(d *schema.ResourceData, met
 
 	request := mariadb.NewModifyDBInstanceSecurityGroupsRequest()
 
-	idSplit := strings.Split(d.Id(), FILED_SP)
-	if len(idSplit) != 3 {
-		return fmt.Errorf("id is broken,%s", d.Id())
+	instanceId, _, securityGroupId, product, err := parseMariadbSecurityGroupsId(d.Id())
+	if err != nil {
+		return err
 	}
-	instanceId := idSplit[0]
-	securityGroupId := idSplit[1]
-	product := idSplit[2]
 
 	request.InstanceId = &instanceId
 	request.SecurityGroupIds = []*string{&securityGroupId}
@@ -170,6 +418,10 @@ func resourceTencentCloudMariadbSecurityGroupsUpdate(d *schema.ResourceData, met
 		return fmt.Errorf("`instance_id` do not support change now.")
 	}
 
+	if d.HasChange("instance_group_id") {
+		return fmt.Errorf("`instance_group_id` do not support change now.")
+	}
+
 	if d.HasChange("security_group_id") {
 		return fmt.Errorf("`security_group_id` do not support change now.")
 	}
@@ -178,7 +430,22 @@ func resourceTencentCloudMariadbSecurityGroupsUpdate(d *schema.ResourceData, met
 		return fmt.Errorf("`product` do not support change now.")
 	}
 
-	err := resource.Retry(writeRetryTimeout, func() *resource.RetryError {
+	err = resource.Retry(writeRetryTimeout, func() *resource.RetryError {
+		if product == TDSQL_PRODUCT_DCDB {
+			dcdbRequest := dcdb.NewModifyDBInstanceSecurityGroupsRequest()
+			dcdbRequest.Product = &product
+			dcdbRequest.InstanceId = &instanceId
+			dcdbRequest.SecurityGroupIds = []*string{&securityGroupId}
+			ratelimit.Check(dcdbRequest.GetAction())
+			result, e := meta.(*TencentCloudClient).apiV3Conn.UseDcdbClient().ModifyDBInstanceSecurityGroups(dcdbRequest)
+			if e != nil {
+				return retryError(e)
+			}
+			log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n",
+				logId, dcdbRequest.GetAction(), dcdbRequest.ToJsonString(), result.ToJsonString())
+			return nil
+		}
+		ratelimit.Check(request.GetAction())
 		result, e := meta.(*TencentCloudClient).apiV3Conn.UseMariadbClient().ModifyDBInstanceSecurityGroups(request)
 		if e != nil {
 			return retryError(e)
@@ -206,13 +473,31 @@ func resourceTencentCloudMariadbSecurityGroupsDelete(d *schema.ResourceData, met
 
 	service := MariadbService{client: meta.(*TencentCloudClient).apiV3Conn}
 
-	idSplit := strings.Split(d.Id(), FILED_SP)
-	if len(idSplit) != 3 {
-		return fmt.Errorf("id is broken,%s", d.Id())
+	instanceId, groupId, securityGroupId, product, err := parseMariadbSecurityGroupsId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if groupId != "" {
+		members, err := service.DescribeMariadbInstancesByGroup(ctx, groupId)
+		if err != nil {
+			return err
+		}
+		for _, member := range members {
+			if err := service.DeleteMariadbSecurityGroupsById(ctx, member, securityGroupId, product); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if product == TDSQL_PRODUCT_DCDB {
+		dcdbService := DcdbService{client: meta.(*TencentCloudClient).apiV3Conn}
+		if err := dcdbService.DeleteDcdbSecurityGroupAttachmentById(ctx, instanceId, securityGroupId); err != nil {
+			return err
+		}
+		return nil
 	}
-	instanceId := idSplit[0]
-	securityGroupId := idSplit[1]
-	product := idSplit[2]
 
 	if err := service.DeleteMariadbSecurityGroupsById(ctx, instanceId, securityGroupId, product); err != nil {
 		return err
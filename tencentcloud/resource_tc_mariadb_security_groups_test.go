@@ -1,11 +1,35 @@
 package tencentcloud
 
 import (
+	"context"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	sdkErrors "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/errors"
+	mariadb "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/mariadb/v20170312"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
 )
 
+func TestCheckMariadbSecurityGroupScope(t *testing.T) {
+	if err := checkMariadbSecurityGroupScope("tdsql-4pzs5b67", "sg-7kpsbxdb", true, "ap-guangzhou"); err != nil {
+		t.Fatalf("expected a visible security group to pass validation, got: %v", err)
+	}
+
+	err := checkMariadbSecurityGroupScope("tdsql-4pzs5b67", "sg-7kpsbxdb", false, "ap-guangzhou")
+	if err == nil {
+		t.Fatalf("expected an error for a security group outside the instance's region")
+	}
+	for _, want := range []string{"sg-7kpsbxdb", "ap-guangzhou", "tdsql-4pzs5b67"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("error should mention %s, got: %v", want, err)
+		}
+	}
+}
+
 // go test -i; go test -test.run TestAccTencentCloudMariadbSecurityGroups_basic -v
 func TestAccTencentCloudMariadbSecurityGroups_basic(t *testing.T) {
 	t.Parallel()
@@ -44,3 +68,173 @@ resource "tencentcloud_mariadb_security_groups" "security_groups" {
 }
 
 `
+
+func TestMariadbSecurityGroupModifyRetryOnResourceInUse(t *testing.T) {
+	// the instance is mid-operation on the first two attempts; the shared
+	// classifier treats the ResourceInUse family as transient by default
+	attempts := 0
+	err := resource.Retry(time.Minute, func() *resource.RetryError {
+		attempts++
+		if attempts < 3 {
+			return retryError(sdkErrors.NewTencentCloudSDKError("ResourceInUse.TempInstanceExist", "instance busy", "req-id"))
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("in-use-then-success sequence should end in success: %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestFlattenMariadbResourceTags(t *testing.T) {
+	tags := flattenMariadbResourceTags([]*mariadb.ResourceTag{
+		{TagKey: helper.String("env"), TagValue: helper.String("prod")},
+		{TagKey: helper.String("broken"), TagValue: nil},
+	})
+	if len(tags) != 1 || tags["env"] != "prod" {
+		t.Errorf("unexpected flattened tags: %v", tags)
+	}
+	if len(flattenMariadbResourceTags(nil)) != 0 {
+		t.Errorf("expected no tags for an untagged instance")
+	}
+}
+
+func TestAccTencentCloudMariadbSecurityGroups_outOfBandUnbind(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMariadbSecurityGroups,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("tencentcloud_mariadb_security_groups.security_groups", "id"),
+					// unbind the security group behind terraform's back
+					func(s *terraform.State) error {
+						rs, ok := s.RootModule().Resources["tencentcloud_mariadb_security_groups.security_groups"]
+						if !ok {
+							return fmt.Errorf("resource tencentcloud_mariadb_security_groups.security_groups is not found")
+						}
+						idSplit := strings.Split(rs.Primary.ID, FILED_SP)
+						if len(idSplit) != 3 {
+							return fmt.Errorf("id is broken,%s", rs.Primary.ID)
+						}
+						ctx := context.WithValue(context.TODO(), logIdKey, getLogId(contextNil))
+						service := MariadbService{client: testAccProvider.Meta().(*TencentCloudClient).apiV3Conn}
+						return service.DeleteMariadbSecurityGroupsById(ctx, idSplit[0], idSplit[1], idSplit[2])
+					},
+				),
+				// the vanished association must plan as a re-create, not error
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccTencentCloudMariadbSecurityGroups_dcdb(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccMariadbSecurityGroupsDcdb, defaultDcdbInstanceId, defaultDcdbSGId),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("tencentcloud_mariadb_security_groups.dcdb", "id"),
+					resource.TestCheckResourceAttr("tencentcloud_mariadb_security_groups.dcdb", "product", "dcdb"),
+				),
+			},
+			{
+				ResourceName:      "tencentcloud_mariadb_security_groups.dcdb",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+const testAccMariadbSecurityGroupsDcdb = `
+resource "tencentcloud_mariadb_security_groups" "dcdb" {
+  instance_id       = "%s"
+  security_group_id = "%s"
+  product           = "dcdb"
+}
+`
+
+func TestMariadbGroupBindings(t *testing.T) {
+	// a two-instance group where both members carry the security group
+	bound := map[string]bool{"tdsql-aaaa1111": true, "tdsql-bbbb2222": true}
+	bindings, anyBound, err := mariadbGroupBindings([]string{"tdsql-aaaa1111", "tdsql-bbbb2222"}, func(instanceId string) (bool, error) {
+		return bound[instanceId], nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !anyBound {
+		t.Error("expected the binding to be reported as present")
+	}
+	if bindings["tdsql-aaaa1111"] != "true" || bindings["tdsql-bbbb2222"] != "true" {
+		t.Errorf("unexpected bindings: %v", bindings)
+	}
+
+	// one member losing the group out of band still counts as bound overall
+	bound["tdsql-bbbb2222"] = false
+	bindings, anyBound, err = mariadbGroupBindings([]string{"tdsql-aaaa1111", "tdsql-bbbb2222"}, func(instanceId string) (bool, error) {
+		return bound[instanceId], nil
+	})
+	if err != nil || !anyBound {
+		t.Fatalf("expected a partial binding to remain, got err %v, anyBound %v", err, anyBound)
+	}
+	if bindings["tdsql-bbbb2222"] != "false" {
+		t.Errorf("expected the unbound member to be tracked as false, got %v", bindings)
+	}
+
+	// no member bound means the resource is gone
+	_, anyBound, err = mariadbGroupBindings([]string{"tdsql-aaaa1111"}, func(instanceId string) (bool, error) {
+		return false, nil
+	})
+	if err != nil || anyBound {
+		t.Errorf("expected no remaining binding, got err %v, anyBound %v", err, anyBound)
+	}
+
+	// a describe failure surfaces instead of guessing
+	wantErr := fmt.Errorf("describe failed")
+	if _, _, err := mariadbGroupBindings([]string{"tdsql-aaaa1111"}, func(instanceId string) (bool, error) {
+		return false, wantErr
+	}); err != wantErr {
+		t.Errorf("expected the describe error to surface, got %v", err)
+	}
+}
+
+func TestParseMariadbSecurityGroupsId(t *testing.T) {
+	// the plain three-segment form is a per-instance binding
+	instanceId, groupId, securityGroupId, product, err := parseMariadbSecurityGroupsId("tdsql-4pzs5b67#sg-7kpsbxdb#mariadb")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if instanceId != "tdsql-4pzs5b67" || groupId != "" || securityGroupId != "sg-7kpsbxdb" || product != "mariadb" {
+		t.Errorf("unexpected instance form parse: %q %q %q %q", instanceId, groupId, securityGroupId, product)
+	}
+
+	// the marker segment selects the group form, so an import without state
+	// attributes still takes the group path
+	instanceId, groupId, securityGroupId, product, err = parseMariadbSecurityGroupsId("group#dbdc-q810131s#sg-7kpsbxdb#mariadb")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if instanceId != "" || groupId != "dbdc-q810131s" || securityGroupId != "sg-7kpsbxdb" || product != "mariadb" {
+		t.Errorf("unexpected group form parse: %q %q %q %q", instanceId, groupId, securityGroupId, product)
+	}
+
+	// anything else is a broken id
+	for _, id := range []string{"tdsql-4pzs5b67#sg-7kpsbxdb", "other#dbdc-q810131s#sg-7kpsbxdb#mariadb"} {
+		if _, _, _, _, err := parseMariadbSecurityGroupsId(id); err == nil {
+			t.Errorf("expected id %q to be rejected", id)
+		}
+	}
+}
@@ -23,6 +23,7 @@ package tencentcloud
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
@@ -54,6 +55,11 @@ func resourceTencentCloudProtocolTemplate() *schema.Resource {
 				Required:    true,
 				Description: "Protocol list. Valid protocols are  `tcp`, `udp`, `icmp`, `gre`. Single port(tcp:80), multi-port(tcp:80,443), port range(tcp:3306-20000), all(tcp:all) format are support. Protocol `icmp` and `gre` cannot specify port.",
 			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Description of the protocol template. The API stores it as the remark on each protocol entry.",
+			},
 		},
 	}
 }
@@ -65,6 +71,7 @@ func resourceTencentCloudProtocolTemplateCreate(d *schema.ResourceData, meta int
 
 	name := d.Get("name").(string)
 	protocols := d.Get("protocols").(*schema.Set).List()
+	description := d.Get("description").(string)
 
 	vpcProtocol := VpcService{
 		client: meta.(*TencentCloudClient).apiV3Conn,
@@ -73,7 +80,7 @@ func resourceTencentCloudProtocolTemplateCreate(d *schema.ResourceData, meta int
 	var templateId string
 
 	outErr = resource.Retry(writeRetryTimeout, func() *resource.RetryError {
-		templateId, inErr = vpcProtocol.CreateServiceTemplate(ctx, name, protocols)
+		templateId, inErr = vpcProtocol.CreateServiceTemplate(ctx, name, protocols, description)
 		if inErr != nil {
 			return retryError(inErr)
 		}
@@ -117,11 +124,26 @@ func resourceTencentCloudProtocolTemplateRead(d *schema.ResourceData, meta inter
 	}
 
 	_ = d.Set("name", template.ServiceTemplateName)
-	_ = d.Set("protocols", template.ServiceSet)
+	_ = d.Set("protocols", normalizeProtocolTemplateServices(template.ServiceSet))
+	_ = d.Set("description", serviceTemplateDescription(template.ServiceExtraSet))
 
 	return nil
 }
 
+// normalizeProtocolTemplateServices lowercases the service entries returned
+// by the API so an imported template matches the lowercase `proto:port` form
+// the schema validates, instead of producing a failed plan.
+func normalizeProtocolTemplateServices(serviceSet []*string) []string {
+	services := make([]string, 0, len(serviceSet))
+	for _, service := range serviceSet {
+		if service == nil {
+			continue
+		}
+		services = append(services, strings.ToLower(*service))
+	}
+	return services
+}
+
 func resourceTencentCloudProtocolTemplateUpdate(d *schema.ResourceData, meta interface{}) error {
 	defer logElapsed("resource.tencentcloud_protocol_template.update")()
 
@@ -129,13 +151,14 @@ func resourceTencentCloudProtocolTemplateUpdate(d *schema.ResourceData, meta int
 	ctx := context.WithValue(context.TODO(), logIdKey, logId)
 	templateId := d.Id()
 
-	if d.HasChange("name") || d.HasChange("protocols") {
+	if d.HasChange("name") || d.HasChange("protocols") || d.HasChange("description") {
 		var outErr, inErr error
 		name := d.Get("name").(string)
 		protocols := d.Get("protocols").(*schema.Set).List()
+		description := d.Get("description").(string)
 		vpcProtocol := VpcService{client: meta.(*TencentCloudClient).apiV3Conn}
 		outErr = resource.Retry(writeRetryTimeout, func() *resource.RetryError {
-			inErr = vpcProtocol.ModifyServiceTemplate(ctx, templateId, name, protocols)
+			inErr = vpcProtocol.ModifyServiceTemplate(ctx, templateId, name, protocols, description)
 			if inErr != nil {
 				return retryError(inErr, "UnsupportedOperation.MutexOperationTaskRunning")
 			}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 )
 
@@ -111,3 +112,17 @@ resource "tencentcloud_protocol_template_group" "group"{
 	template_ids = [tencentcloud_protocol_template.templateB.id]
 }
 `
+
+func TestProtocolTemplateGroupTemplateIdsDedup(t *testing.T) {
+	// template_ids is a set, so duplicate ids collapse before the API call and
+	// the silently-deduplicated answer can not drift from state
+	raw := resourceTencentCloudProtocolTemplateGroup().Schema["template_ids"]
+	if raw.Type != schema.TypeSet {
+		t.Fatalf("template_ids must stay a set to keep duplicate ids out of the plan")
+	}
+
+	set := schema.NewSet(schema.HashString, []interface{}{"ppm-x1", "ppm-x1", "ppm-x2"})
+	if set.Len() != 2 {
+		t.Errorf("expected duplicate template ids to collapse, got %d entries", set.Len())
+	}
+}
@@ -21,6 +21,7 @@ func TestAccTencentCloudProtocolTemplate_basic_and_update(t *testing.T) {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("tencentcloud_protocol_template.template", "name", "test"),
 					resource.TestCheckResourceAttr("tencentcloud_protocol_template.template", "protocols.#", "1"),
+					resource.TestCheckResourceAttr("tencentcloud_protocol_template.template", "description", "test template"),
 				),
 			},
 			{
@@ -87,6 +88,7 @@ const testAccProtocolTemplate_basic = `
 resource "tencentcloud_protocol_template" "template" {
   name = "test"
   protocols = ["tcp:80"]
+  description = "test template"
 }`
 
 const testAccProtocolTemplate_basic_update_remark = `
@@ -94,3 +96,39 @@ resource "tencentcloud_protocol_template" "template" {
   name = "test_update"
   protocols = ["udp:all", "tcp:80,90"]
 }`
+
+func TestServiceTemplateDescriptionRoundTrip(t *testing.T) {
+	protocols := []interface{}{"tcp:80", "udp:all"}
+
+	extras := buildServicesInfo(protocols, "web ports")
+	if len(extras) != 2 || *extras[0].Service != "tcp:80" || *extras[1].Service != "udp:all" {
+		t.Fatalf("unexpected services extra: %+v", extras)
+	}
+
+	// the description written onto the entries reads back unchanged
+	if got := serviceTemplateDescription(extras); got != "web ports" {
+		t.Errorf("expected description to round-trip, got %q", got)
+	}
+
+	// templates created without remarks have no description
+	if got := serviceTemplateDescription(nil); got != "" {
+		t.Errorf("expected empty description, got %q", got)
+	}
+}
+
+func TestNormalizeProtocolTemplateServices(t *testing.T) {
+	// an API answer with uppercase protocols is folded to the validated form
+	tcp := "TCP:80"
+	udp := "udp:all"
+	icmp := "Icmp:10-30"
+	services := normalizeProtocolTemplateServices([]*string{&tcp, &udp, nil, &icmp})
+	want := []string{"tcp:80", "udp:all", "icmp:10-30"}
+	if len(services) != len(want) {
+		t.Fatalf("expected %v, got %v", want, services)
+	}
+	for i := range want {
+		if services[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, services)
+		}
+	}
+}
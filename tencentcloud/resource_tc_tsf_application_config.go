@@ -28,13 +28,20 @@ package tencentcloud
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"log"
+	"reflect"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	tsf "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/tsf/v20180326"
 	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/ratelimit"
+	"gopkg.in/yaml.v2"
 )
 
 func resourceTencentCloudTsfApplicationConfig() *schema.Resource {
@@ -60,9 +67,27 @@ func resourceTencentCloudTsfApplicationConfig() *schema.Resource {
 			},
 
 			"config_value": {
-				Required:    true,
+				Optional:      true,
+				Computed:      true,
+				Type:          schema.TypeString,
+				ConflictsWith: []string{"config_value_template"},
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return suppressEquivalentTsfConfigValueDiff(d.Get("config_type").(string), old, new)
+				},
+				Description: "configuration item value. Conflict with `config_value_template`, one of them must be set.",
+			},
+
+			"config_value_template": {
+				Optional:      true,
+				Type:          schema.TypeString,
+				ConflictsWith: []string{"config_value"},
+				Description:   "Rendered configuration item value, usually produced by terraform `templatefile`. Conflict with `config_value`, one of them must be set.",
+			},
+
+			"config_value_hash": {
+				Computed:    true,
 				Type:        schema.TypeString,
-				Description: "configuration item value.",
+				Description: "SHA256 hash of the stored configuration item value, used for drift detection.",
 			},
 
 			"application_id": {
@@ -103,10 +128,94 @@ func resourceTencentCloudTsfApplicationConfig() *schema.Resource {
 			// 	Optional:    true,
 			// 	Description: "Tag description list.",
 			// },
+
+			"versions": {
+				Computed:    true,
+				Type:        schema.TypeList,
+				Description: "All versions of this configuration item, informational only.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"version": {
+							Computed:    true,
+							Type:        schema.TypeString,
+							Description: "configuration item version.",
+						},
+						"desc": {
+							Computed:    true,
+							Type:        schema.TypeString,
+							Description: "configuration item version description.",
+						},
+						"create_time": {
+							Computed:    true,
+							Type:        schema.TypeString,
+							Description: "creation time of the version.",
+						},
+					},
+				},
+			},
 		},
 	}
 }
 
+// suppressEquivalentTsfConfigValueDiff suppresses config_value diffs that are
+// formatting only: when config_type marks the value as YAML, old and new are
+// compared after parsing, so whitespace or key order changes the API
+// normalizes away do not show up as drift. Other types keep plain string
+// comparison.
+func suppressEquivalentTsfConfigValueDiff(configType, old, new string) bool {
+	if old == new {
+		return true
+	}
+	switch strings.ToLower(configType) {
+	case "y", "yaml", "yml":
+	default:
+		return false
+	}
+	var oldValue, newValue interface{}
+	if err := yaml.Unmarshal([]byte(old), &oldValue); err != nil {
+		return false
+	}
+	if err := yaml.Unmarshal([]byte(new), &newValue); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(oldValue, newValue)
+}
+
+// tsfConfigValueForState maps the API's configuration value back to the form
+// kept in state. With encode_with_base64 the user supplies base64 while the
+// API stores the decoded plaintext, so the value is re-encoded to match the
+// configuration. A value the API kept in base64 form is left untouched to
+// avoid double encoding.
+func tsfConfigValueForState(apiValue string, encodeWithBase64 bool) string {
+	if !encodeWithBase64 {
+		return apiValue
+	}
+	if decoded, err := base64.StdEncoding.DecodeString(apiValue); err == nil &&
+		base64.StdEncoding.EncodeToString(decoded) == apiValue {
+		return apiValue
+	}
+	return base64.StdEncoding.EncodeToString([]byte(apiValue))
+}
+
+// tsfCreateConfigFalseError explains a CreateConfig call that came back with
+// Result=false. The response carries no message next to the flag, so the
+// error names the config, points at an existing config with the same name
+// when one is found, and includes the request id for support tickets.
+func tsfCreateConfigFalseError(action, configName, configVersion string, requestId *string, existing *tsf.Config) error {
+	msg := fmt.Sprintf("api[%s] failed to create config `%s` version `%s`", action, configName, configVersion)
+	if existing != nil && existing.ConfigId != nil {
+		if existing.ConfigVersion != nil && *existing.ConfigVersion == configVersion {
+			msg += fmt.Sprintf(", config `%s` already exists with the same name and version", *existing.ConfigId)
+		} else {
+			msg += fmt.Sprintf(", a config with the same name already exists as `%s`", *existing.ConfigId)
+		}
+	}
+	if requestId != nil {
+		msg += fmt.Sprintf(" (request id: %s)", *requestId)
+	}
+	return fmt.Errorf(msg)
+}
+
 func resourceTencentCloudTsfApplicationConfigCreate(d *schema.ResourceData, meta interface{}) error {
 	defer logElapsed("resource.tencentcloud_tsf_application_config.create")()
 	defer inconsistentCheck(d, meta)()
@@ -129,9 +238,17 @@ func resourceTencentCloudTsfApplicationConfigCreate(d *schema.ResourceData, meta
 		request.ConfigVersion = helper.String(v.(string))
 	}
 
+	var configValue string
 	if v, ok := d.GetOk("config_value"); ok {
-		request.ConfigValue = helper.String(v.(string))
+		configValue = v.(string)
 	}
+	if v, ok := d.GetOk("config_value_template"); ok {
+		configValue = v.(string)
+	}
+	if configValue == "" {
+		return fmt.Errorf("one of `config_value` and `config_value_template` must be set")
+	}
+	request.ConfigValue = helper.String(configValue)
 
 	if v, ok := d.GetOk("application_id"); ok {
 		request.ApplicationId = helper.String(v.(string))
@@ -158,11 +275,12 @@ func resourceTencentCloudTsfApplicationConfigCreate(d *schema.ResourceData, meta
 	}
 
 	err := resource.Retry(writeRetryTimeout, func() *resource.RetryError {
+		ratelimit.Check(request.GetAction())
 		result, e := meta.(*TencentCloudClient).apiV3Conn.UseTsfClient().CreateConfig(request)
 		if e != nil {
 			return retryError(e)
 		} else {
-			log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n", logId, request.GetAction(), request.ToJsonString(), result.ToJsonString())
+			log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n", logId, request.GetAction(), redactRequestBody(request.ToJsonString()), result.ToJsonString())
 		}
 		response = result
 		return nil
@@ -182,7 +300,15 @@ func resourceTencentCloudTsfApplicationConfigCreate(d *schema.ResourceData, meta
 		configId = *applicationConfig.ConfigId
 		d.SetId(configId)
 	} else {
-		return fmt.Errorf("[DEBUG]%s api[%s] Creation failed, and the return result of interface creation is false", logId, request.GetAction())
+		//the flag carries no message, look the name up to explain the usual
+		//cause, a config that already exists with the same name and version
+		var existing *tsf.Config
+		service := TsfService{client: meta.(*TencentCloudClient).apiV3Conn}
+		if conflict, e := service.DescribeTsfApplicationConfigById(ctx, "", configName); e == nil {
+			existing = conflict
+		}
+		return tsfCreateConfigFalseError(request.GetAction(), configName,
+			d.Get("config_version").(string), response.Response.RequestId, existing)
 	}
 
 	return resourceTencentCloudTsfApplicationConfigRead(d, meta)
@@ -200,7 +326,15 @@ func resourceTencentCloudTsfApplicationConfigRead(d *schema.ResourceData, meta i
 
 	configId := d.Id()
 
-	applicationConfig, err := service.DescribeTsfApplicationConfigById(ctx, configId, "")
+	var applicationConfig *tsf.Config
+	err := helper.RetryDescribe(ctx, readRetryTimeout, func() error {
+		result, e := service.DescribeTsfApplicationConfigById(ctx, configId, "")
+		if e != nil {
+			return e
+		}
+		applicationConfig = result
+		return nil
+	})
 	if err != nil {
 		return err
 	}
@@ -220,7 +354,10 @@ func resourceTencentCloudTsfApplicationConfigRead(d *schema.ResourceData, meta i
 	}
 
 	if applicationConfig.ConfigValue != nil {
-		_ = d.Set("config_value", applicationConfig.ConfigValue)
+		configValue := tsfConfigValueForState(*applicationConfig.ConfigValue, d.Get("encode_with_base64").(bool))
+		_ = d.Set("config_value", configValue)
+		valueHash := sha256.Sum256([]byte(configValue))
+		_ = d.Set("config_value_hash", hex.EncodeToString(valueHash[:]))
 	}
 
 	if applicationConfig.ApplicationId != nil {
@@ -243,9 +380,45 @@ func resourceTencentCloudTsfApplicationConfigRead(d *schema.ResourceData, meta i
 	// 	_ = d.Set("program_id_list", applicationConfig.ProgramIdList)
 	// }
 
+	if applicationConfig.ConfigName != nil && applicationConfig.ApplicationId != nil {
+		var configs []*tsf.Config
+		err = helper.RetryDescribe(ctx, readRetryTimeout, func() error {
+			result, e := service.DescribeTsfApplicationConfigVersions(ctx, *applicationConfig.ApplicationId, *applicationConfig.ConfigName)
+			if e != nil {
+				return e
+			}
+			configs = result
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		_ = d.Set("versions", tsfConfigVersionList(configs))
+	}
+
 	return nil
 }
 
+// tsfConfigVersionList maps the configs sharing one name within an
+// application to the informational `versions` attribute.
+func tsfConfigVersionList(configs []*tsf.Config) []map[string]interface{} {
+	versions := make([]map[string]interface{}, 0, len(configs))
+	for _, config := range configs {
+		version := map[string]interface{}{}
+		if config.ConfigVersion != nil {
+			version["version"] = *config.ConfigVersion
+		}
+		if config.ConfigVersionDesc != nil {
+			version["desc"] = *config.ConfigVersionDesc
+		}
+		if config.CreationTime != nil {
+			version["create_time"] = *config.CreationTime
+		}
+		versions = append(versions, version)
+	}
+	return versions
+}
+
 func resourceTencentCloudTsfApplicationConfigUpdate(d *schema.ResourceData, meta interface{}) error {
 	defer logElapsed("resource.tencentcloud_tsf_microservice.update")()
 	defer inconsistentCheck(d, meta)()
@@ -254,6 +427,7 @@ func resourceTencentCloudTsfApplicationConfigUpdate(d *schema.ResourceData, meta
 		"config_name",
 		"config_version",
 		"config_value",
+		"config_value_template",
 		"application_id",
 		"config_version_desc",
 		"config_type",
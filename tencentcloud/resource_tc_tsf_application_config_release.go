@@ -0,0 +1,201 @@
+/*
+Provides a resource to release a tsf application_config to a deployment group
+
+Example Usage
+
+```hcl
+resource "tencentcloud_tsf_application_config_release" "application_config_release" {
+  config_id    = tencentcloud_tsf_application_config.application_config.id
+  group_id     = "group-yrjkln9v"
+  release_desc = "released by terraform"
+}
+```
+
+Import
+
+tsf application_config_release can be imported using the configId#groupId, e.g.
+
+```
+terraform import tencentcloud_tsf_application_config_release.application_config_release dcfg-y4e3zngv#group-yrjkln9v
+```
+*/
+package tencentcloud
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	tsf "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/tsf/v20180326"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/ratelimit"
+)
+
+func resourceTencentCloudTsfApplicationConfigRelease() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceTencentCloudTsfApplicationConfigReleaseCreate,
+		Read:   resourceTencentCloudTsfApplicationConfigReleaseRead,
+		Delete: resourceTencentCloudTsfApplicationConfigReleaseDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"config_id": {
+				Required:    true,
+				ForceNew:    true,
+				Type:        schema.TypeString,
+				Description: "Configuration item ID.",
+			},
+
+			"group_id": {
+				Required:    true,
+				ForceNew:    true,
+				Type:        schema.TypeString,
+				Description: "Deployment group ID the configuration is released to.",
+			},
+
+			"release_desc": {
+				Optional:    true,
+				ForceNew:    true,
+				Type:        schema.TypeString,
+				Description: "Release description.",
+			},
+
+			"config_release_id": {
+				Computed:    true,
+				Type:        schema.TypeString,
+				Description: "Configuration item release ID.",
+			},
+
+			"release_time": {
+				Computed:    true,
+				Type:        schema.TypeString,
+				Description: "Release time.",
+			},
+		},
+	}
+}
+
+func resourceTencentCloudTsfApplicationConfigReleaseCreate(d *schema.ResourceData, meta interface{}) error {
+	defer logElapsed("resource.tencentcloud_tsf_application_config_release.create")()
+	defer inconsistentCheck(d, meta)()
+
+	logId := getLogId(contextNil)
+
+	var (
+		request  = tsf.NewReleaseConfigRequest()
+		configId = d.Get("config_id").(string)
+		groupId  = d.Get("group_id").(string)
+	)
+	request.ConfigId = helper.String(configId)
+	request.GroupId = helper.String(groupId)
+
+	if v, ok := d.GetOk("release_desc"); ok {
+		request.ReleaseDesc = helper.String(v.(string))
+	}
+
+	err := resource.Retry(writeRetryTimeout, func() *resource.RetryError {
+		ratelimit.Check(request.GetAction())
+		result, e := meta.(*TencentCloudClient).apiV3Conn.UseTsfClient().ReleaseConfig(request)
+		if e != nil {
+			return retryError(e)
+		}
+		log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n", logId, request.GetAction(), request.ToJsonString(), result.ToJsonString())
+		if result.Response.Result == nil || !*result.Response.Result {
+			return resource.NonRetryableError(fmt.Errorf("api[%s] release failed, and the return result of interface release is false", request.GetAction()))
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("[CRITAL]%s create tsf applicationConfigRelease failed, reason:%+v", logId, err)
+		return err
+	}
+
+	d.SetId(configId + FILED_SP + groupId)
+
+	return resourceTencentCloudTsfApplicationConfigReleaseRead(d, meta)
+}
+
+func resourceTencentCloudTsfApplicationConfigReleaseRead(d *schema.ResourceData, meta interface{}) error {
+	defer logElapsed("resource.tencentcloud_tsf_application_config_release.read")()
+	defer inconsistentCheck(d, meta)()
+
+	logId := getLogId(contextNil)
+	ctx := context.WithValue(context.TODO(), logIdKey, logId)
+
+	service := TsfService{client: meta.(*TencentCloudClient).apiV3Conn}
+
+	idSplit := strings.Split(d.Id(), FILED_SP)
+	if len(idSplit) != 2 {
+		return fmt.Errorf("id is broken,%s", d.Id())
+	}
+	configId := idSplit[0]
+	groupId := idSplit[1]
+
+	configRelease, err := service.DescribeTsfApplicationConfigReleaseById(ctx, configId, groupId)
+	if err != nil {
+		return err
+	}
+
+	if configRelease == nil {
+		d.SetId("")
+		log.Printf("[WARN]%s resource `TsfApplicationConfigRelease` [%s] not found, please check if it has been deleted.\n", logId, d.Id())
+		return nil
+	}
+
+	_ = d.Set("config_id", configId)
+	_ = d.Set("group_id", groupId)
+
+	if configRelease.ReleaseDesc != nil {
+		_ = d.Set("release_desc", configRelease.ReleaseDesc)
+	}
+
+	if configRelease.ConfigReleaseId != nil {
+		_ = d.Set("config_release_id", configRelease.ConfigReleaseId)
+	}
+
+	if configRelease.ReleaseTime != nil {
+		_ = d.Set("release_time", configRelease.ReleaseTime)
+	}
+
+	return nil
+}
+
+func resourceTencentCloudTsfApplicationConfigReleaseDelete(d *schema.ResourceData, meta interface{}) error {
+	defer logElapsed("resource.tencentcloud_tsf_application_config_release.delete")()
+	defer inconsistentCheck(d, meta)()
+
+	logId := getLogId(contextNil)
+	ctx := context.WithValue(context.TODO(), logIdKey, logId)
+
+	service := TsfService{client: meta.(*TencentCloudClient).apiV3Conn}
+
+	idSplit := strings.Split(d.Id(), FILED_SP)
+	if len(idSplit) != 2 {
+		return fmt.Errorf("id is broken,%s", d.Id())
+	}
+	configId := idSplit[0]
+	groupId := idSplit[1]
+
+	configRelease, err := service.DescribeTsfApplicationConfigReleaseById(ctx, configId, groupId)
+	if err != nil {
+		return err
+	}
+
+	if configRelease == nil {
+		return nil
+	}
+
+	if configRelease.ConfigReleaseId == nil {
+		return fmt.Errorf("tsf application config release %s return id is nil", d.Id())
+	}
+
+	if err := service.RevokeTsfApplicationConfigRelease(ctx, *configRelease.ConfigReleaseId); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package tencentcloud
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+// go test -i; go test -test.run TestAccTencentCloudTsfApplicationConfigReleaseResource_basic -v
+func TestAccTencentCloudTsfApplicationConfigReleaseResource_basic(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckTsfApplicationConfigReleaseDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTsfApplicationConfigRelease,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTsfApplicationConfigReleaseExists("tencentcloud_tsf_application_config_release.application_config_release"),
+					resource.TestCheckResourceAttrSet("tencentcloud_tsf_application_config_release.application_config_release", "config_id"),
+					resource.TestCheckResourceAttrSet("tencentcloud_tsf_application_config_release.application_config_release", "config_release_id"),
+					resource.TestCheckResourceAttrSet("tencentcloud_tsf_application_config_release.application_config_release", "release_time"),
+					resource.TestCheckResourceAttr("tencentcloud_tsf_application_config_release.application_config_release", "release_desc", "released by terraform"),
+				),
+			},
+			{
+				ResourceName:      "tencentcloud_tsf_application_config_release.application_config_release",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckTsfApplicationConfigReleaseDestroy(s *terraform.State) error {
+	logId := getLogId(contextNil)
+	ctx := context.WithValue(context.TODO(), logIdKey, logId)
+	service := TsfService{client: testAccProvider.Meta().(*TencentCloudClient).apiV3Conn}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "tencentcloud_tsf_application_config_release" {
+			continue
+		}
+
+		idSplit := strings.Split(rs.Primary.ID, FILED_SP)
+		if len(idSplit) != 2 {
+			return fmt.Errorf("id is broken,%s", rs.Primary.ID)
+		}
+
+		res, err := service.DescribeTsfApplicationConfigReleaseById(ctx, idSplit[0], idSplit[1])
+		if err != nil {
+			return err
+		}
+
+		if res != nil {
+			return fmt.Errorf("tsf application config release %s still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAccCheckTsfApplicationConfigReleaseExists(r string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		logId := getLogId(contextNil)
+		ctx := context.WithValue(context.TODO(), logIdKey, logId)
+
+		rs, ok := s.RootModule().Resources[r]
+		if !ok {
+			return fmt.Errorf("resource %s is not found", r)
+		}
+
+		idSplit := strings.Split(rs.Primary.ID, FILED_SP)
+		if len(idSplit) != 2 {
+			return fmt.Errorf("id is broken,%s", rs.Primary.ID)
+		}
+
+		service := TsfService{client: testAccProvider.Meta().(*TencentCloudClient).apiV3Conn}
+		res, err := service.DescribeTsfApplicationConfigReleaseById(ctx, idSplit[0], idSplit[1])
+		if err != nil {
+			return err
+		}
+
+		if res == nil {
+			return fmt.Errorf("tsf application config release %s is not found", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+const testAccTsfApplicationConfigReleaseVar = testAccTsfApplicationConfigVar + `
+variable "group_id" {
+	default = "group-yrjkln9v"
+}
+`
+
+const testAccTsfApplicationConfigRelease = testAccTsfApplicationConfigReleaseVar + `
+
+resource "tencentcloud_tsf_application_config" "application_config" {
+	config_name = "tf-test-config-release"
+	config_version = "1.0"
+	config_value = "name: \"name\""
+	application_id = var.application_id
+	config_version_desc = "version desc"
+}
+
+resource "tencentcloud_tsf_application_config_release" "application_config_release" {
+	config_id = tencentcloud_tsf_application_config.application_config.id
+	group_id = var.group_id
+	release_desc = "released by terraform"
+}
+
+`
@@ -3,10 +3,13 @@ package tencentcloud
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	tsf "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/tsf/v20180326"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
 )
 
 // go test -i; go test -test.run TestAccTencentCloudTsfApplicationConfigResource_basic -v
@@ -29,6 +32,7 @@ func TestAccTencentCloudTsfApplicationConfigResource_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("tencentcloud_tsf_application_config.application_config", "config_version", "1.0"),
 					resource.TestCheckResourceAttr("tencentcloud_tsf_application_config.application_config", "config_value", "name: \"name\""),
 					resource.TestCheckResourceAttr("tencentcloud_tsf_application_config.application_config", "config_version_desc", "version desc"),
+					resource.TestCheckResourceAttrSet("tencentcloud_tsf_application_config.application_config", "versions.0.version"),
 					// resource.TestCheckResourceAttr("tencentcloud_tsf_application_config.application_config", "encode_with_base64", "false"),
 				),
 			},
@@ -41,6 +45,29 @@ func TestAccTencentCloudTsfApplicationConfigResource_basic(t *testing.T) {
 	})
 }
 
+// go test -i; go test -test.run TestAccTencentCloudTsfApplicationConfigResource_template -v
+func TestAccTencentCloudTsfApplicationConfigResource_template(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckTsfApplicationConfigDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTsfApplicationConfigTemplate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTsfApplicationConfigExists("tencentcloud_tsf_application_config.application_config"),
+					resource.TestCheckResourceAttr("tencentcloud_tsf_application_config.application_config", "config_value", "name: \"rendered\""),
+					resource.TestCheckResourceAttrSet("tencentcloud_tsf_application_config.application_config", "config_value_hash"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckTsfApplicationConfigDestroy(s *terraform.State) error {
 	logId := getLogId(contextNil)
 	ctx := context.WithValue(context.TODO(), logIdKey, logId)
@@ -106,3 +133,162 @@ resource "tencentcloud_tsf_application_config" "application_config" {
 }
 
 `
+
+const testAccTsfApplicationConfigTemplate = testAccTsfApplicationConfigVar + `
+
+resource "tencentcloud_tsf_application_config" "application_config" {
+	config_name = "tf-test-config-tpl"
+	config_version = "1.0"
+	config_value_template = "name: \"rendered\""
+	application_id = var.application_id
+	config_version_desc = "version desc"
+}
+
+`
+
+func TestSuppressEquivalentTsfConfigValueDiff(t *testing.T) {
+	// reordered keys and extra whitespace are the same YAML document
+	old := "name: test\nport: 8080\n"
+	new := "port:   8080\nname: test"
+	if !suppressEquivalentTsfConfigValueDiff("yaml", old, new) {
+		t.Errorf("expected reordered YAML keys to be suppressed")
+	}
+	if !suppressEquivalentTsfConfigValueDiff("Y", old, new) {
+		t.Errorf("expected the short YAML type marker to be recognized")
+	}
+
+	// a real value change must keep the diff
+	if suppressEquivalentTsfConfigValueDiff("yaml", old, "name: test\nport: 9090\n") {
+		t.Errorf("expected a changed value to show a diff")
+	}
+
+	// non-YAML types fall back to string comparison
+	if suppressEquivalentTsfConfigValueDiff("properties", old, new) {
+		t.Errorf("expected non-YAML types to compare as strings")
+	}
+	if !suppressEquivalentTsfConfigValueDiff("properties", old, old) {
+		t.Errorf("expected identical strings to be suppressed regardless of type")
+	}
+
+	// unparsable content keeps the diff instead of guessing
+	if suppressEquivalentTsfConfigValueDiff("yaml", "{unclosed", new) {
+		t.Errorf("expected invalid YAML to keep the diff")
+	}
+}
+
+func TestTsfCreateConfigFalseError(t *testing.T) {
+	requestId := "b8e6ba8a-f9c3-47c8-9c1c-0a367e949c32"
+
+	// a conflicting config with the same version is called out explicitly
+	err := tsfCreateConfigFalseError("CreateConfig", "test-config", "1.0", &requestId, &tsf.Config{
+		ConfigId:      helper.String("dcfg-y4e3zngv"),
+		ConfigVersion: helper.String("1.0"),
+	})
+	for _, want := range []string{"test-config", "1.0", "dcfg-y4e3zngv", "same name and version", requestId} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error to contain %q, got: %v", want, err)
+		}
+	}
+
+	// same name but another version is reported differently
+	err = tsfCreateConfigFalseError("CreateConfig", "test-config", "2.0", &requestId, &tsf.Config{
+		ConfigId:      helper.String("dcfg-y4e3zngv"),
+		ConfigVersion: helper.String("1.0"),
+	})
+	if !strings.Contains(err.Error(), "same name already exists") {
+		t.Errorf("expected a name-only conflict message, got: %v", err)
+	}
+
+	// without a conflict the error still names config and request id
+	err = tsfCreateConfigFalseError("CreateConfig", "test-config", "1.0", nil, nil)
+	if !strings.Contains(err.Error(), "test-config") || strings.Contains(err.Error(), "request id") {
+		t.Errorf("unexpected error without conflict and request id: %v", err)
+	}
+}
+
+// go test -i; go test -test.run TestAccTencentCloudTsfApplicationConfigResource_base64 -v
+func TestAccTencentCloudTsfApplicationConfigResource_base64(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckTsfApplicationConfigDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTsfApplicationConfigBase64,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTsfApplicationConfigExists("tencentcloud_tsf_application_config.application_config"),
+					// the state keeps the base64 form the configuration supplied
+					resource.TestCheckResourceAttr("tencentcloud_tsf_application_config.application_config", "config_value", "bmFtZTogIm5hbWUi"),
+				),
+			},
+			{
+				// the re-encoded read value must not leave a perpetual diff
+				Config:             testAccTsfApplicationConfigBase64,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			},
+		},
+	})
+}
+
+const testAccTsfApplicationConfigBase64 = testAccTsfApplicationConfigVar + `
+
+resource "tencentcloud_tsf_application_config" "application_config" {
+	config_name = "tf-test-config-b64"
+	config_version = "1.0"
+	# base64 of: name: "name"
+	config_value = "bmFtZTogIm5hbWUi"
+	application_id = var.application_id
+	config_version_desc = "version desc"
+	encode_with_base64 = true
+}
+
+`
+
+func TestTsfConfigValueForState(t *testing.T) {
+	// without base64 encoding the API value is kept verbatim
+	if got := tsfConfigValueForState("name: \"name\"", false); got != "name: \"name\"" {
+		t.Errorf("expected plain value to pass through, got %q", got)
+	}
+
+	// the API decoded the supplied base64, the state re-encodes it
+	if got := tsfConfigValueForState("name: \"name\"", true); got != "bmFtZTogIm5hbWUi" {
+		t.Errorf("expected the value to be re-encoded, got %q", got)
+	}
+
+	// a value the API kept in base64 form must not be encoded twice
+	if got := tsfConfigValueForState("bmFtZTogIm5hbWUi", true); got != "bmFtZTogIm5hbWUi" {
+		t.Errorf("expected base64 value to be kept as-is, got %q", got)
+	}
+}
+
+func TestTsfConfigVersionList(t *testing.T) {
+	v1, v2 := "1.0", "2.0"
+	desc := "second version"
+	createTime := "2023-03-01 10:00:00"
+	versions := tsfConfigVersionList([]*tsf.Config{
+		{ConfigVersion: &v1},
+		{ConfigVersion: &v2, ConfigVersionDesc: &desc, CreationTime: &createTime},
+	})
+	if len(versions) != 2 {
+		t.Fatalf("expected both versions to be listed, got %d", len(versions))
+	}
+	if versions[0]["version"] != "1.0" || versions[1]["version"] != "2.0" {
+		t.Errorf("unexpected version order: %v", versions)
+	}
+	if versions[1]["desc"] != desc || versions[1]["create_time"] != createTime {
+		t.Errorf("expected desc and create_time to be carried over, got %v", versions[1])
+	}
+	// a version with no optional fields still appears, with only its number
+	if _, ok := versions[0]["desc"]; ok {
+		t.Errorf("expected no desc for a version without one, got %v", versions[0])
+	}
+
+	if got := tsfConfigVersionList(nil); len(got) != 0 {
+		t.Errorf("expected an empty list for no configs, got %v", got)
+	}
+}
@@ -62,6 +62,13 @@ func resourceTencentCloudVpcBandwidthPackage() *schema.Resource {
 				Description: "Bandwidth package name.",
 			},
 
+			"internet_max_bandwidth": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Bandwidth package rate limit in Mbps, `-1` means no limit.",
+			},
+
 			"tags": {
 				Type:        schema.TypeMap,
 				Optional:    true,
@@ -94,6 +101,10 @@ func resourceTencentCloudVpcBandwidthPackageCreate(d *schema.ResourceData, meta
 		request.BandwidthPackageName = helper.String(v.(string))
 	}
 
+	if v, ok := d.GetOkExists("internet_max_bandwidth"); ok {
+		request.InternetMaxBandwidth = helper.IntInt64(v.(int))
+	}
+
 	if v := helper.GetTags(d, "tags"); len(v) > 0 {
 		for tagKey, tagValue := range v {
 			tag := vpc.Tag{
@@ -194,6 +205,10 @@ func resourceTencentCloudVpcBandwidthPackageRead(d *schema.ResourceData, meta in
 		_ = d.Set("bandwidth_package_name", bandwidthPackage.BandwidthPackageName)
 	}
 
+	if bandwidthPackage.Bandwidth != nil {
+		_ = d.Set("internet_max_bandwidth", int(*bandwidthPackage.Bandwidth))
+	}
+
 	tcClient := meta.(*TencentCloudClient).apiV3Conn
 	tagService := &TagService{client: tcClient}
 	tags, err := tagService.DescribeResourceTags(ctx, "vpc", "bandwidthPackage", tcClient.Region, d.Id())
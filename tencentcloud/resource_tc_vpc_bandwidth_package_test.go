@@ -25,6 +25,7 @@ func TestAccTencentCloudVpcBandwidthPackage_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("tencentcloud_vpc_bandwidth_package.bandwidth_package", "bandwidth_package_name", "iac-test-001"),
 					resource.TestCheckResourceAttr("tencentcloud_vpc_bandwidth_package.bandwidth_package", "charge_type", "TOP5_POSTPAID_BY_MONTH"),
 					resource.TestCheckResourceAttr("tencentcloud_vpc_bandwidth_package.bandwidth_package", "network_type", "BGP"),
+					resource.TestCheckResourceAttrSet("tencentcloud_vpc_bandwidth_package.bandwidth_package", "internet_max_bandwidth"),
 				),
 			},
 			{
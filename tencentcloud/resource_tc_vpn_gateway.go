@@ -1,7 +1,7 @@
 /*
 Provides a resource to create a VPN gateway.
 
--> **NOTE:** The prepaid VPN gateway do not support renew operation or delete operation with terraform.
+-> **NOTE:** The prepaid VPN gateway do not support delete operation with terraform before it expires. Changing `prepaid_period` renews the gateway and extends the subscription.
 
 Example Usage
 
@@ -49,15 +49,31 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/errors"
 	vpc "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/vpc/v20170312"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/connectivity"
 	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/ratelimit"
 )
 
+// vpnGatewayTagLocks serializes tag updates per gateway id; parallel applies
+// touching the same gateway would otherwise contend on the tag API
+var vpnGatewayTagLocks sync.Map
+
+// lockVpnGatewayTags takes the per-gateway tag lock and returns the unlock
+// function
+func lockVpnGatewayTags(gatewayId string) func() {
+	value, _ := vpnGatewayTagLocks.LoadOrStore(gatewayId, &sync.Mutex{})
+	mu := value.(*sync.Mutex)
+	mu.Lock()
+	return mu.Unlock
+}
+
 func resourceTencentCloudVpnGateway() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceTencentCloudVpnGatewayCreate,
@@ -65,7 +81,42 @@ func resourceTencentCloudVpnGateway() *schema.Resource {
 		Update: resourceTencentCloudVpnGatewayUpdate,
 		Delete: resourceTencentCloudVpnGatewayDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				//reject ids from the wrong region up front instead of importing an empty resource
+				ctx := context.WithValue(context.TODO(), logIdKey, getLogId(contextNil))
+				service := VpcService{client: meta.(*TencentCloudClient).apiV3Conn}
+				has, _, err := service.DescribeVpngwById(ctx, d.Id())
+				if err != nil {
+					return nil, err
+				}
+				if !has {
+					tcClient := meta.(*TencentCloudClient)
+					if region, pErr := describeAcrossRegions(tcClient.apiV3Conn, tcClient.importFallbackRegions, func(regionClient *connectivity.TencentCloudClient) (bool, error) {
+						regionService := VpcService{client: regionClient}
+						found, _, e := regionService.DescribeVpngwById(ctx, d.Id())
+						return found, e
+					}); pErr == nil && region != "" {
+						return nil, fmt.Errorf("VPN gateway `%s` not found in region `%s`, it lives in region `%s`",
+							d.Id(), tcClient.apiV3Conn.Region, region)
+					}
+					return nil, fmt.Errorf("VPN gateway `%s` not found in region `%s`, check your provider region",
+						d.Id(), tcClient.apiV3Conn.Region)
+				}
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+		CustomizeDiff: func(d *schema.ResourceDiff, meta interface{}) error {
+			//reject bandwidth tiers the selected charge type does not offer at plan time
+			if err := checkVpnGatewayBandwidth(d.Get("charge_type").(string), d.Get("bandwidth").(int)); err != nil {
+				return err
+			}
+			//catch zone typos at plan time instead of deep in the create API
+			if v, ok := d.GetOk("zone"); ok {
+				ctx := context.WithValue(context.TODO(), logIdKey, getLogId(contextNil))
+				cvmService := CvmService{client: meta.(*TencentCloudClient).apiV3Conn}
+				return cvmService.CheckZoneAvailable(ctx, v.(string))
+			}
+			return nil
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -80,10 +131,8 @@ func resourceTencentCloudVpnGateway() *schema.Resource {
 				Optional: true,
 				ForceNew: true,
 				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
-					if v, ok := d.GetOk("type"); ok && v.(string) == "CCN" {
-						return true
-					}
-					return old == new
+					oldType, newType := d.GetChange("type")
+					return shouldSuppressVpnGatewayVpcIdDiff(oldType.(string), newType.(string), old, new)
 				},
 				Description: "ID of the VPC. Required if vpn gateway is not in `CCN` type, and doesn't make sense for `CCN` vpn gateway.",
 			},
@@ -120,7 +169,7 @@ func resourceTencentCloudVpnGateway() *schema.Resource {
 				Optional:     true,
 				Default:      1,
 				ValidateFunc: validateAllowedIntValue([]int{1, 2, 3, 4, 6, 7, 8, 9, 12, 24, 36}),
-				Description:  "Period of instance to be prepaid. Valid value: `1`, `2`, `3`, `4`, `6`, `7`, `8`, `9`, `12`, `24`, `36`. The unit is month. Caution: when this para and renew_flag para are valid, the request means to renew several months more pre-paid period. This para can only be set to take effect in create operation.",
+				Description:  "Period of instance to be prepaid. Valid value: `1`, `2`, `3`, `4`, `6`, `7`, `8`, `9`, `12`, `24`, `36`. The unit is month. Caution: changing this value on a `PREPAID` gateway renews the gateway, which extends the subscription by the new period.",
 			},
 			"charge_type": {
 				Type:        schema.TypeString,
@@ -134,12 +183,28 @@ func resourceTencentCloudVpnGateway() *schema.Resource {
 				Computed:    true,
 				Description: "CDC instance ID.",
 			},
+			"ccn_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "ID of the CCN instance to attach the gateway to. Only makes sense for `CCN` type gateway, the attachment is created after the gateway and removed before the gateway is destroyed.",
+			},
+			"network_instance_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the network instance the gateway is attached to, for `CCN` type gateway it is the CCN instance ID.",
+			},
 			"max_connection": {
 				Type:        schema.TypeInt,
 				Optional:    true,
 				Computed:    true,
 				Description: "Maximum number of connected clients allowed for the SSL VPN gateway. Valid values: [5, 10, 20, 50, 100]. This parameter is only required for SSL VPN gateways.",
 			},
+			"ssl_active_connection_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of SSL VPN clients currently connected to the gateway. Always `0` for non-SSL gateways.",
+			},
 			"expired_time": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -160,6 +225,17 @@ func resourceTencentCloudVpnGateway() *schema.Resource {
 				Computed:    true,
 				Description: "Restrict state of gateway. Valid value: `PRETECIVELY_ISOLATED`, `NORMAL`.",
 			},
+			"blocked_reason": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Reason the public ip is blocked, derived from the restrict state when `is_address_blocked` is true. Empty for an unblocked gateway.",
+			},
+			"force_delete": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Indicates whether to delete the associated VPN connections when deleting the gateway. Default is `false`, refusing the delete while connections still exist.",
+			},
 			"zone": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -176,6 +252,11 @@ func resourceTencentCloudVpnGateway() *schema.Resource {
 				Computed:    true,
 				Description: "Create time of the VPN gateway.",
 			},
+			"resource_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The fully-qualified QCS resource name of the VPN gateway, which can be used in CAM policies and tag automation.",
+			},
 		},
 	}
 }
@@ -221,6 +302,14 @@ func resourceTencentCloudVpnGatewayCreate(d *schema.ResourceData, meta interface
 		request.VpcId = helper.String(d.Get("vpc_id").(string))
 	}
 
+	ccnId := ""
+	if v, ok := d.GetOk("ccn_id"); ok {
+		if t, ok := d.GetOk("type"); !ok || t.(string) != GATE_WAY_TYPE_CCN {
+			return fmt.Errorf("[CRITAL] ccn_id only makes sense when vpn gateway is in CCN type")
+		}
+		ccnId = v.(string)
+	}
+
 	if v, ok := d.GetOk("cdc_id"); ok {
 		request.CdcId = helper.String(v.(string))
 	}
@@ -229,12 +318,32 @@ func resourceTencentCloudVpnGatewayCreate(d *schema.ResourceData, meta interface
 		request.MaxConnection = helper.IntUint64(v.(int))
 	}
 
+	//CreateVpnGateway doesn't accept a client token, so a create interrupted by
+	//a network error may have succeeded on the server side. Before retrying,
+	//adopt the gateway created by the previous attempt to keep retries idempotent.
+	service := VpcService{client: meta.(*TencentCloudClient).apiV3Conn}
 	var response *vpc.CreateVpnGatewayResponse
+	var gatewayId string
+	//only gateways created after this point are adoption candidates; allow a
+	//minute of clock skew between this host and the API
+	attemptStart := time.Now().Add(-time.Minute)
 	err := resource.Retry(readRetryTimeout, func() *resource.RetryError {
+		ratelimit.Check(request.GetAction())
 		result, e := meta.(*TencentCloudClient).apiV3Conn.UseVpcClient().CreateVpnGateway(request)
 		if e != nil {
 			log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
 				logId, request.GetAction(), request.ToJsonString(), e.Error())
+			if isExpectError(e, []string{"ClientError.NetworkError", "ClientError.HttpStatusCodeError"}) {
+				gateways, dErr := service.DescribeVpnGwByFilter(ctx, map[string]string{
+					"vpn-gateway-name": d.Get("name").(string),
+				})
+				if dErr == nil {
+					if id, ok := matchCreatedVpnGateway(gateways, d.Get("name").(string), d.Get("zone").(string), attemptStart); ok {
+						gatewayId = id
+						return nil
+					}
+				}
+			}
 			return retryError(e)
 		}
 		response = result
@@ -245,16 +354,19 @@ func resourceTencentCloudVpnGatewayCreate(d *schema.ResourceData, meta interface
 		return err
 	}
 
-	if response.Response.VpnGateway == nil {
-		return fmt.Errorf("VPN gateway id is nil")
+	if gatewayId == "" {
+		if response.Response.VpnGateway == nil {
+			return fmt.Errorf("VPN gateway id is nil")
+		}
+		gatewayId = *response.Response.VpnGateway.VpnGatewayId
 	}
-	gatewayId := *response.Response.VpnGateway.VpnGatewayId
 	d.SetId(gatewayId)
 
 	// must wait for creating gateway finished
 	statRequest := vpc.NewDescribeVpnGatewaysRequest()
 	statRequest.VpnGatewayIds = []*string{helper.String(gatewayId)}
 	err = resource.Retry(2*readRetryTimeout, func() *resource.RetryError {
+		ratelimit.Check(statRequest.GetAction())
 		result, e := meta.(*TencentCloudClient).apiV3Conn.UseVpcClient().DescribeVpnGateways(statRequest)
 		if e != nil {
 			log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
@@ -278,6 +390,21 @@ func resourceTencentCloudVpnGatewayCreate(d *schema.ResourceData, meta interface
 		return err
 	}
 
+	//attach the CCN type gateway to its CCN instance once it is available
+	if ccnId != "" {
+		region := meta.(*TencentCloudClient).apiV3Conn.Region
+		err = resource.Retry(writeRetryTimeout, func() *resource.RetryError {
+			if e := service.AttachCcnInstances(ctx, ccnId, region, CNN_INSTANCE_TYPE_VPNGW, gatewayId, ""); e != nil {
+				return retryError(e)
+			}
+			return nil
+		})
+		if err != nil {
+			log.Printf("[CRITAL]%s attach VPN gateway to CCN failed, reason:%s\n", logId, err.Error())
+			return err
+		}
+	}
+
 	//modify tags
 	if tags := helper.GetTags(d, "tags"); len(tags) > 0 {
 		tagService := TagService{client: meta.(*TencentCloudClient).apiV3Conn}
@@ -293,6 +420,32 @@ func resourceTencentCloudVpnGatewayCreate(d *schema.ResourceData, meta interface
 	return resourceTencentCloudVpnGatewayRead(d, meta)
 }
 
+// matchCreatedVpnGateway returns the id of the only gateway matching the name and
+// zone of a create request and created after the attempt started, so a retried
+// create can return the gateway created by a previous attempt instead of creating
+// a duplicate. Gateway names are not unique; without the creation time bound a
+// pre-existing gateway sharing the name would be adopted and later destroyed even
+// though Terraform never created it.
+func matchCreatedVpnGateway(gateways []*vpc.VpnGateway, name, zone string, createdAfter time.Time) (string, bool) {
+	matched := make([]*vpc.VpnGateway, 0, 1)
+	for _, gateway := range gateways {
+		if gateway.VpnGatewayId == nil || gateway.VpnGatewayName == nil || gateway.Zone == nil || gateway.CreatedTime == nil {
+			continue
+		}
+		if *gateway.VpnGatewayName != name || *gateway.Zone != zone {
+			continue
+		}
+		if stringTotime(*gateway.CreatedTime).Before(createdAfter) {
+			continue
+		}
+		matched = append(matched, gateway)
+	}
+	if len(matched) != 1 {
+		return "", false
+	}
+	return *matched[0].VpnGatewayId, true
+}
+
 func resourceTencentCloudVpnGatewayRead(d *schema.ResourceData, meta interface{}) error {
 	defer logElapsed("resource.tencentcloud_vpn_gateway.read")()
 	defer inconsistentCheck(d, meta)()
@@ -304,7 +457,14 @@ func resourceTencentCloudVpnGatewayRead(d *schema.ResourceData, meta interface{}
 		gatewayId = d.Id()
 	)
 
-	has, gateway, err := service.DescribeVpngwById(ctx, gatewayId)
+	var (
+		has     bool
+		gateway *vpc.VpnGateway
+	)
+	err := helper.RetryDescribe(ctx, readRetryTimeout, func() (e error) {
+		has, gateway, e = service.DescribeVpngwById(ctx, gatewayId)
+		return e
+	})
 	if err != nil {
 		log.Printf("[CRITAL]%s read VPN gateway failed, reason:%s\n", logId, err.Error())
 		return err
@@ -325,10 +485,31 @@ func resourceTencentCloudVpnGatewayRead(d *schema.ResourceData, meta interface{}
 	_ = d.Set("expired_time", gateway.ExpiredTime)
 	_ = d.Set("is_address_blocked", gateway.IsAddressBlocked)
 	_ = d.Set("new_purchase_plan", gateway.NewPurchasePlan)
+	_ = d.Set("network_instance_id", gateway.NetworkInstanceId)
+	if *gateway.Type == GATE_WAY_TYPE_CCN && gateway.NetworkInstanceId != nil && *gateway.NetworkInstanceId != "" {
+		_ = d.Set("ccn_id", gateway.NetworkInstanceId)
+	}
 	_ = d.Set("restrict_state", gateway.RestrictState)
+	blockedReason := vpnGatewayBlockedReason(gateway)
+	if blockedReason != "" {
+		log.Printf("[WARN]%s VPN gateway %s public ip is blocked: %s", logId, gatewayId, blockedReason)
+	}
+	_ = d.Set("blocked_reason", blockedReason)
 	_ = d.Set("zone", gateway.Zone)
 	_ = d.Set("cdc_id", gateway.CdcId)
 	_ = d.Set("max_connection", gateway.MaxConnection)
+
+	sslActiveConnectionCount := 0
+	if *gateway.Type == "SSL" {
+		clients, err := service.DescribeVpnGwSslClientByFilter(ctx, map[string]string{"vpn-gateway-id": gatewayId})
+		if err != nil {
+			log.Printf("[CRITAL]%s read VPN gateway SSL clients failed, reason:%s\n", logId, err.Error())
+			return err
+		}
+		sslActiveConnectionCount = countConnectedSslVpnClients(clients)
+	}
+	_ = d.Set("ssl_active_connection_count", sslActiveConnectionCount)
+
 	//tags
 	tagService := TagService{client: meta.(*TencentCloudClient).apiV3Conn}
 	region := meta.(*TencentCloudClient).apiV3Conn.Region
@@ -336,11 +517,137 @@ func resourceTencentCloudVpnGatewayRead(d *schema.ResourceData, meta interface{}
 	if err != nil {
 		return err
 	}
+	tags = normalizeResourceTagsCase(tags, helper.GetTags(d, "tags"))
 	_ = d.Set("tags", tags)
 
+	//uin-filled, like the other resources exporting a QCS name, so the value
+	//can be dropped into CAM policy documents as-is; a failed uin lookup keeps
+	//the previous value rather than breaking the refresh
+	camService := CamService{client: meta.(*TencentCloudClient).apiV3Conn}
+	if uin, uinErr := camService.DescribeAccountUin(ctx); uinErr != nil {
+		log.Printf("[WARN]%s describe account uin failed, resource_name is not refreshed: %+v", logId, uinErr)
+	} else {
+		_ = d.Set("resource_name", BuildTagResourceNameWithUin("vpc", "vpngw", region, gatewayId, uin))
+	}
+
 	return nil
 }
 
+// countConnectedSslVpnClients counts the clients whose tunnel is currently
+// established, so utilization can be compared against max_connection.
+func countConnectedSslVpnClients(clients []*vpc.SslVpnClient) int {
+	count := 0
+	for _, client := range clients {
+		if client.State != nil && *client.State == SSL_VPN_CLIENT_STATE_CONNECTED {
+			count++
+		}
+	}
+	return count
+}
+
+// shouldSuppressVpnGatewayVpcIdDiff keeps vpc_id quiet only for a gateway
+// that stays in `CCN` type, where the field has no meaning. A transition out
+// of `CCN` (or any real VPC change) must surface the diff so ForceNew can
+// recreate the gateway.
+func shouldSuppressVpnGatewayVpcIdDiff(oldType, newType, oldVpcId, newVpcId string) bool {
+	if oldType == GATE_WAY_TYPE_CCN && newType == GATE_WAY_TYPE_CCN {
+		return true
+	}
+	return oldVpcId == newVpcId
+}
+
+// checkVpnGatewayBandwidth rejects a bandwidth value the selected charge type
+// does not offer, naming the allowed tiers, so a plan fails fast instead of
+// the create or reset API.
+func checkVpnGatewayBandwidth(chargeType string, bandwidth int) error {
+	tiers, ok := VPN_BANDWIDTH_BY_CHARGE_TYPE[chargeType]
+	if !ok {
+		// an unknown charge type is rejected by its own validation
+		return nil
+	}
+	for _, tier := range tiers {
+		if bandwidth == tier {
+			return nil
+		}
+	}
+	return fmt.Errorf("`bandwidth` %d is not available for charge type `%s`, the available values include: %v", bandwidth, chargeType, tiers)
+}
+
+// vpnGatewayRenameRequest builds an attribute modification that only carries
+// the new name, never InstanceChargeType
+func vpnGatewayRenameRequest(gatewayId, name string) *vpc.ModifyVpnGatewayAttributeRequest {
+	request := vpc.NewModifyVpnGatewayAttributeRequest()
+	request.VpnGatewayId = &gatewayId
+	request.VpnGatewayName = &name
+	return request
+}
+
+// vpnGatewayChargeTypeRequest builds an attribute modification for a charge
+// type change; only pre-paid --> post-paid is valid
+func vpnGatewayChargeTypeRequest(gatewayId, oldChargeType, newChargeType string) (*vpc.ModifyVpnGatewayAttributeRequest, error) {
+	if err := checkChargeTypeSwitch(oldChargeType, newChargeType, VPN_CHARGE_TYPE_PREPAID, VPN_CHARGE_TYPE_POSTPAID_BY_HOUR); err != nil {
+		return nil, err
+	}
+	request := vpc.NewModifyVpnGatewayAttributeRequest()
+	request.VpnGatewayId = &gatewayId
+	if oldChargeType == VPN_CHARGE_TYPE_PREPAID && newChargeType == VPN_CHARGE_TYPE_POSTPAID_BY_HOUR {
+		request.InstanceChargeType = &newChargeType
+	}
+	return request, nil
+}
+
+// waitForVpnGatewayChargeType polls the gateway through describe until its
+// InstanceChargeType reflects the expected value; the charge type switch
+// completes asynchronously on the API side
+func waitForVpnGatewayChargeType(timeout time.Duration, expected string, describe func() (*vpc.VpnGateway, error)) error {
+	return resource.Retry(timeout, func() *resource.RetryError {
+		gateway, err := describe()
+		if err != nil {
+			return retryError(err)
+		}
+		if gateway == nil || gateway.InstanceChargeType == nil {
+			return resource.NonRetryableError(fmt.Errorf("VPN gateway disappeared while waiting for charge type %s", expected))
+		}
+		if *gateway.InstanceChargeType != expected {
+			return resource.RetryableError(fmt.Errorf("VPN gateway charge type is still %s, waiting for %s", *gateway.InstanceChargeType, expected))
+		}
+		return nil
+	})
+}
+
+// vpnGatewayBlockedReason describes why a gateway's public ip is blocked so
+// alerting pipelines can react to it. The API carries no dedicated reason
+// field, so the restrict state is the closest signal; an unblocked gateway
+// yields an empty reason.
+func vpnGatewayBlockedReason(gateway *vpc.VpnGateway) string {
+	if gateway == nil || gateway.IsAddressBlocked == nil || !*gateway.IsAddressBlocked {
+		return ""
+	}
+	if gateway.RestrictState != nil && *gateway.RestrictState == VPN_RESTRICT_STATE_ISOLATE {
+		return "public ip protectively isolated by security"
+	}
+	return "public ip blocked, check the console for the security event"
+}
+
+// waitForVpnGatewayBandwidth polls the gateway through describe until its
+// InternetMaxBandwidthOut reflects the expected value; the bandwidth reset
+// completes asynchronously on the API side
+func waitForVpnGatewayBandwidth(timeout time.Duration, expected uint64, describe func() (*vpc.VpnGateway, error)) error {
+	return resource.Retry(timeout, func() *resource.RetryError {
+		gateway, err := describe()
+		if err != nil {
+			return retryError(err)
+		}
+		if gateway == nil || gateway.InternetMaxBandwidthOut == nil {
+			return resource.NonRetryableError(fmt.Errorf("VPN gateway disappeared while waiting for bandwidth %d", expected))
+		}
+		if *gateway.InternetMaxBandwidthOut != expected {
+			return resource.RetryableError(fmt.Errorf("VPN gateway bandwidth is still %d, waiting for %d", *gateway.InternetMaxBandwidthOut, expected))
+		}
+		return nil
+	})
+}
+
 func resourceTencentCloudVpnGatewayUpdate(d *schema.ResourceData, meta interface{}) error {
 	defer logElapsed("resource.tencentcloud_vpn_gateway.update")()
 
@@ -351,7 +658,6 @@ func resourceTencentCloudVpnGatewayUpdate(d *schema.ResourceData, meta interface
 	gatewayId := d.Id()
 
 	unsupportedUpdateFields := []string{
-		"prepaid_period",
 		"type",
 	}
 	for _, field := range unsupportedUpdateFields {
@@ -360,11 +666,38 @@ func resourceTencentCloudVpnGatewayUpdate(d *schema.ResourceData, meta interface
 		}
 	}
 
+	//changing prepaid_period renews the gateway for the new period
+	if d.HasChange("prepaid_period") {
+		if err := checkChargeTypeOperation("prepaid_period change", d.Get("charge_type").(string), VPN_CHARGE_TYPE_PREPAID); err != nil {
+			return err
+		}
+		request := vpc.NewRenewVpnGatewayRequest()
+		request.VpnGatewayId = &gatewayId
+		var preChargePara vpc.InstanceChargePrepaid
+		preChargePara.Period = helper.IntUint64(d.Get("prepaid_period").(int))
+		request.InstanceChargePrepaid = &preChargePara
+		err := resource.Retry(writeRetryTimeout, func() *resource.RetryError {
+			ratelimit.Check(request.GetAction())
+			_, e := meta.(*TencentCloudClient).apiV3Conn.UseVpcClient().RenewVpnGateway(request)
+			if e != nil {
+				log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
+					logId, request.GetAction(), request.ToJsonString(), e.Error())
+				return retryError(e)
+			}
+			return nil
+		})
+		if err != nil {
+			log.Printf("[CRITAL]%s renew VPN gateway failed, reason:%s\n", logId, err.Error())
+			return err
+		}
+		d.SetPartial("prepaid_period")
+	}
+
 	if d.HasChange("prepaid_renew_flag") {
 		chargeType := d.Get("charge_type").(string)
 		renewFlag := d.Get("prepaid_renew_flag").(string)
-		if chargeType != VPN_CHARGE_TYPE_PREPAID {
-			return fmt.Errorf("Invalid renew flag change. Only support pre-paid vpn.")
+		if err := checkChargeTypeOperation("prepaid_renew_flag change", chargeType, VPN_CHARGE_TYPE_PREPAID); err != nil {
+			return err
 		}
 		request := vpc.NewSetVpnGatewaysRenewFlagRequest()
 		request.VpnGatewayIds = []*string{&gatewayId}
@@ -375,6 +708,7 @@ func resourceTencentCloudVpnGatewayUpdate(d *schema.ResourceData, meta interface
 		}
 
 		err := resource.Retry(readRetryTimeout, func() *resource.RetryError {
+			ratelimit.Check(request.GetAction())
 			_, e := meta.(*TencentCloudClient).apiV3Conn.UseVpcClient().SetVpnGatewaysRenewFlag(request)
 			if e != nil {
 				log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
@@ -389,21 +723,12 @@ func resourceTencentCloudVpnGatewayUpdate(d *schema.ResourceData, meta interface
 		}
 	}
 
-	if d.HasChange("name") || d.HasChange("charge_type") {
-		//check that the charge type change is valid
-		//only pre-paid --> post-paid is valid
-		oldInterface, newInterface := d.GetChange("charge_type")
-		oldChargeType := oldInterface.(string)
-		newChargeType := newInterface.(string)
-		request := vpc.NewModifyVpnGatewayAttributeRequest()
-		request.VpnGatewayId = &gatewayId
-		request.VpnGatewayName = helper.String(d.Get("name").(string))
-		if oldChargeType == VPN_CHARGE_TYPE_PREPAID && newChargeType == VPN_CHARGE_TYPE_POSTPAID_BY_HOUR {
-			request.InstanceChargeType = &newChargeType
-		} else if oldChargeType == VPN_CHARGE_TYPE_POSTPAID_BY_HOUR && newChargeType == VPN_CHARGE_TYPE_PREPAID {
-			return fmt.Errorf("Invalid charge type change. Only support pre-paid to post-paid way.")
-		}
+	//a pure rename must never carry the charge type, so the two updates are
+	//sent as separate ModifyVpnGatewayAttribute calls
+	if d.HasChange("name") {
+		request := vpnGatewayRenameRequest(gatewayId, d.Get("name").(string))
 		err := resource.Retry(readRetryTimeout, func() *resource.RetryError {
+			ratelimit.Check(request.GetAction())
 			_, e := meta.(*TencentCloudClient).apiV3Conn.UseVpcClient().ModifyVpnGatewayAttribute(request)
 			if e != nil {
 				log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
@@ -416,12 +741,47 @@ func resourceTencentCloudVpnGatewayUpdate(d *schema.ResourceData, meta interface
 			log.Printf("[CRITAL]%s modify VPN gateway name failed, reason:%s\n", logId, err.Error())
 			return err
 		}
-		if d.HasChange("name") {
-			d.SetPartial("name")
+		d.SetPartial("name")
+	}
+
+	if d.HasChange("charge_type") {
+		oldInterface, newInterface := d.GetChange("charge_type")
+		request, rErr := vpnGatewayChargeTypeRequest(gatewayId, oldInterface.(string), newInterface.(string))
+		if rErr != nil {
+			return rErr
+		}
+		err := resource.Retry(readRetryTimeout, func() *resource.RetryError {
+			ratelimit.Check(request.GetAction())
+			_, e := meta.(*TencentCloudClient).apiV3Conn.UseVpcClient().ModifyVpnGatewayAttribute(request)
+			if e != nil {
+				log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
+					logId, request.GetAction(), request.ToJsonString(), e.Error())
+				return retryError(e)
+			}
+			return nil
+		})
+		if err != nil {
+			log.Printf("[CRITAL]%s modify VPN gateway charge type failed, reason:%s\n", logId, err.Error())
+			return err
 		}
-		if d.HasChange("charge_type") {
-			d.SetPartial("charge_type")
+		//the switch is asynchronous, wait until the gateway reports the new
+		//charge type so the follow-up read does not leave a diff
+		service := VpcService{client: meta.(*TencentCloudClient).apiV3Conn}
+		err = waitForVpnGatewayChargeType(readRetryTimeout, newInterface.(string), func() (*vpc.VpnGateway, error) {
+			has, gateway, e := service.DescribeVpngwById(ctx, gatewayId)
+			if e != nil {
+				return nil, e
+			}
+			if !has {
+				return nil, nil
+			}
+			return gateway, nil
+		})
+		if err != nil {
+			log.Printf("[CRITAL]%s wait for VPN gateway charge type failed, reason:%s\n", logId, err.Error())
+			return err
 		}
+		d.SetPartial("charge_type")
 	}
 
 	//bandwidth
@@ -432,6 +792,7 @@ func resourceTencentCloudVpnGatewayUpdate(d *schema.ResourceData, meta interface
 		bandwidth64 := uint64(bandwidth)
 		request.InternetMaxBandwidthOut = &bandwidth64
 		err := resource.Retry(readRetryTimeout, func() *resource.RetryError {
+			ratelimit.Check(request.GetAction())
 			_, e := meta.(*TencentCloudClient).apiV3Conn.UseVpcClient().ResetVpnGatewayInternetMaxBandwidth(request)
 			if e != nil {
 				log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
@@ -444,6 +805,23 @@ func resourceTencentCloudVpnGatewayUpdate(d *schema.ResourceData, meta interface
 			log.Printf("[CRITAL]%s modify VPN gateway bandwidth failed, reason:%s\n", logId, err.Error())
 			return err
 		}
+		//the reset is asynchronous, wait until the gateway reports the new
+		//bandwidth so the follow-up read does not leave a diff
+		service := VpcService{client: meta.(*TencentCloudClient).apiV3Conn}
+		err = waitForVpnGatewayBandwidth(readRetryTimeout, bandwidth64, func() (*vpc.VpnGateway, error) {
+			has, gateway, e := service.DescribeVpngwById(ctx, gatewayId)
+			if e != nil {
+				return nil, e
+			}
+			if !has {
+				return nil, nil
+			}
+			return gateway, nil
+		})
+		if err != nil {
+			log.Printf("[CRITAL]%s wait for VPN gateway bandwidth failed, reason:%s\n", logId, err.Error())
+			return err
+		}
 		d.SetPartial("bandwidth")
 	}
 
@@ -456,7 +834,9 @@ func resourceTencentCloudVpnGatewayUpdate(d *schema.ResourceData, meta interface
 		}
 		region := meta.(*TencentCloudClient).apiV3Conn.Region
 		resourceName := BuildTagResourceName("vpc", "vpngw", region, gatewayId)
+		unlock := lockVpnGatewayTags(gatewayId)
 		err := tagService.ModifyTags(ctx, resourceName, replaceTags, deleteTags)
+		unlock()
 		if err != nil {
 			return err
 		}
@@ -476,9 +856,28 @@ func resourceTencentCloudVpnGatewayDelete(d *schema.ResourceData, meta interface
 	defer logElapsed("resource.tencentcloud_vpn_gateway.delete")()
 
 	logId := getLogId(contextNil)
+	ctx := context.WithValue(context.TODO(), logIdKey, logId)
 
 	gatewayId := d.Id()
 
+	//a managed CCN attachment has to be removed before the gateway can be deleted
+	managedCcnAttachment := false
+	if v, ok := d.GetOk("ccn_id"); ok {
+		managedCcnAttachment = true
+		service := VpcService{client: meta.(*TencentCloudClient).apiV3Conn}
+		region := meta.(*TencentCloudClient).apiV3Conn.Region
+		err := resource.Retry(writeRetryTimeout, func() *resource.RetryError {
+			if e := service.DetachCcnInstances(ctx, v.(string), region, CNN_INSTANCE_TYPE_VPNGW, gatewayId); e != nil {
+				return retryError(e)
+			}
+			return nil
+		})
+		if err != nil {
+			log.Printf("[CRITAL]%s detach VPN gateway from CCN failed, reason:%s\n", logId, err.Error())
+			return err
+		}
+	}
+
 	//prepaid instances or instances which attached to ccn can not be deleted
 	//to get expire_time of the VPN gateway
 	//to get the status of gateway
@@ -486,6 +885,7 @@ func resourceTencentCloudVpnGatewayDelete(d *schema.ResourceData, meta interface
 	vpngwRequest := vpc.NewDescribeVpnGatewaysRequest()
 	vpngwRequest.VpnGatewayIds = []*string{&gatewayId}
 	vpngwErr := resource.Retry(readRetryTimeout, func() *resource.RetryError {
+		ratelimit.Check(vpngwRequest.GetAction())
 		result, e := meta.(*TencentCloudClient).apiV3Conn.UseVpcClient().DescribeVpnGateways(vpngwRequest)
 		if e != nil {
 			return retryError(e)
@@ -507,6 +907,10 @@ func resourceTencentCloudVpnGatewayDelete(d *schema.ResourceData, meta interface
 				}
 			}
 			if *result.Response.VpnGatewaySet[0].Type == GATE_WAY_TYPE_CCN && *result.Response.VpnGatewaySet[0].NetworkInstanceId != "" {
+				if managedCcnAttachment {
+					//the detach above may take a moment to take effect
+					return resource.RetryableError(fmt.Errorf("VPN gateway is still attached to CCN instance, wait for detaching."))
+				}
 				return resource.NonRetryableError(fmt.Errorf("Delete operation is unsupported when VPN gateway is attached to CCN instance."))
 			}
 			return nil
@@ -537,7 +941,18 @@ func resourceTencentCloudVpnGatewayDelete(d *schema.ResourceData, meta interface
 	offset := uint64(0)
 	tRequest.Offset = &offset
 
+	forceDelete := d.Get("force_delete").(bool)
+	if forceDelete {
+		//remove the associated tunnels first so the gateway itself can be deleted
+		vpcService := VpcService{client: meta.(*TencentCloudClient).apiV3Conn}
+		if fErr := vpcService.DeleteVpnConnectionsByGateway(ctx, gatewayId); fErr != nil {
+			log.Printf("[CRITAL]%s force delete VPN connections failed, reason:%s\n", logId, fErr.Error())
+			return fErr
+		}
+	}
+
 	tErr := resource.Retry(readRetryTimeout, func() *resource.RetryError {
+		ratelimit.Check(tRequest.GetAction())
 		result, e := meta.(*TencentCloudClient).apiV3Conn.UseVpcClient().DescribeVpnConnections(tRequest)
 
 		if e != nil {
@@ -548,6 +963,10 @@ func resourceTencentCloudVpnGatewayDelete(d *schema.ResourceData, meta interface
 			if len(result.Response.VpnConnectionSet) == 0 {
 				return nil
 			} else {
+				if forceDelete {
+					//deletions above may take a moment to disappear from describe
+					return resource.RetryableError(fmt.Errorf("VPN connections are still being deleted, wait for them to disappear."))
+				}
 				return resource.NonRetryableError(fmt.Errorf("There is associated tunnel exists, please delete associated tunnels first."))
 			}
 		}
@@ -561,6 +980,7 @@ func resourceTencentCloudVpnGatewayDelete(d *schema.ResourceData, meta interface
 	request.VpnGatewayId = &gatewayId
 
 	err := resource.Retry(writeRetryTimeout, func() *resource.RetryError {
+		ratelimit.Check(request.GetAction())
 		_, e := meta.(*TencentCloudClient).apiV3Conn.UseVpcClient().DeleteVpnGateway(request)
 		if e != nil {
 			log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
@@ -577,6 +997,7 @@ func resourceTencentCloudVpnGatewayDelete(d *schema.ResourceData, meta interface
 	statRequest := vpc.NewDescribeVpnGatewaysRequest()
 	statRequest.VpnGatewayIds = []*string{&gatewayId}
 	err = resource.Retry(readRetryTimeout, func() *resource.RetryError {
+		ratelimit.Check(statRequest.GetAction())
 		result, e := meta.(*TencentCloudClient).apiV3Conn.UseVpcClient().DescribeVpnGateways(statRequest)
 		if e != nil {
 			ee, ok := e.(*errors.TencentCloudSDKError)
@@ -4,7 +4,10 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"regexp"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -12,6 +15,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/errors"
 	vpc "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/vpc/v20170312"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
 )
 
 func init() {
@@ -160,6 +164,165 @@ func testAccCheckVpnGatewayDestroy(s *terraform.State) error {
 	return nil
 }
 
+func TestAccTencentCloudVpnGateway_prepaidRenew(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVpnGatewayDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPrepaidVpnGatewayConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpnGatewayExists("tencentcloud_vpn_gateway.my_prepaid_cgw"),
+					resource.TestCheckResourceAttr("tencentcloud_vpn_gateway.my_prepaid_cgw", "charge_type", "PREPAID"),
+					resource.TestCheckResourceAttr("tencentcloud_vpn_gateway.my_prepaid_cgw", "prepaid_period", "1"),
+				),
+			},
+			{
+				// bumping prepaid_period renews the gateway for the new period
+				Config: testAccPrepaidVpnGatewayConfigRenew,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpnGatewayExists("tencentcloud_vpn_gateway.my_prepaid_cgw"),
+					resource.TestCheckResourceAttr("tencentcloud_vpn_gateway.my_prepaid_cgw", "prepaid_period", "3"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccTencentCloudVpnGateway_bogusZone(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				// a zone typo must be rejected at plan time
+				Config:      testAccVpnGatewayConfigBogusZone,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("is not available in region"),
+			},
+		},
+	})
+}
+
+func TestAccTencentCloudVpnGateway_ccnAttachment(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVpnGatewayDestroy,
+		Steps: []resource.TestStep{
+			{
+				// the attachment is created after the gateway and removed again
+				// by CheckDestroy, which fails if the gateway cannot be deleted
+				Config: testAccCcnAttachedVpnGatewayConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpnGatewayExists("tencentcloud_vpn_gateway.my_attached_ccn_cgw"),
+					resource.TestCheckResourceAttr("tencentcloud_vpn_gateway.my_attached_ccn_cgw", "type", "CCN"),
+					resource.TestCheckResourceAttrSet("tencentcloud_vpn_gateway.my_attached_ccn_cgw", "ccn_id"),
+					resource.TestCheckResourceAttrSet("tencentcloud_vpn_gateway.my_attached_ccn_cgw", "network_instance_id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccTencentCloudVpnGateway_forceDelete(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVpnGatewayDestroy,
+		Steps: []resource.TestStep{
+			{
+				// the connection only exists in the cloud, not in state, so the
+				// gateway destroy has to remove the tunnel itself
+				Config: testAccForceDeleteVpnGatewayConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpnGatewayExists("tencentcloud_vpn_gateway.my_force_cgw"),
+					resource.TestCheckResourceAttr("tencentcloud_vpn_gateway.my_force_cgw", "force_delete", "true"),
+					testAccVpnGatewayCreateDanglingConnection("tencentcloud_vpn_gateway.my_force_cgw"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccTencentCloudVpnGateway_importNotFound(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				// importing an id from the wrong region must fail instead of
+				// producing an empty resource
+				Config:        testAccVpnGatewayConfig,
+				ResourceName:  "tencentcloud_vpn_gateway.my_cgw",
+				ImportState:   true,
+				ImportStateId: "vpngw-00000000",
+				ExpectError:   regexp.MustCompile("not found in region"),
+			},
+		},
+	})
+}
+
+func TestMatchCreatedVpnGateway(t *testing.T) {
+	attemptStart := stringTotime("2023-06-01 12:00:00")
+	created := "2023-06-01 12:00:30"
+	gateways := []*vpc.VpnGateway{
+		{
+			VpnGatewayId:   helper.String("vpngw-aaaaaaaa"),
+			VpnGatewayName: helper.String("terraform_test"),
+			Zone:           helper.String("ap-guangzhou-3"),
+			CreatedTime:    helper.String(created),
+		},
+		{
+			VpnGatewayId:   helper.String("vpngw-bbbbbbbb"),
+			VpnGatewayName: helper.String("terraform_other"),
+			Zone:           helper.String("ap-guangzhou-3"),
+			CreatedTime:    helper.String(created),
+		},
+	}
+
+	// a retried create adopts the gateway created by the previous attempt
+	id, ok := matchCreatedVpnGateway(gateways, "terraform_test", "ap-guangzhou-3", attemptStart)
+	if !ok || id != "vpngw-aaaaaaaa" {
+		t.Errorf("expected vpngw-aaaaaaaa, got %s (matched: %v)", id, ok)
+	}
+
+	// no match in another zone
+	if _, ok := matchCreatedVpnGateway(gateways, "terraform_test", "ap-guangzhou-4", attemptStart); ok {
+		t.Errorf("unexpected match in another zone")
+	}
+
+	// a gateway that pre-existed the create attempt must not be adopted even
+	// when it is the only name+zone match; names are not unique
+	preExisting := []*vpc.VpnGateway{
+		{
+			VpnGatewayId:   helper.String("vpngw-dddddddd"),
+			VpnGatewayName: helper.String("terraform_test"),
+			Zone:           helper.String("ap-guangzhou-3"),
+			CreatedTime:    helper.String("2023-05-31 09:00:00"),
+		},
+	}
+	if _, ok := matchCreatedVpnGateway(preExisting, "terraform_test", "ap-guangzhou-3", attemptStart); ok {
+		t.Errorf("unexpected adoption of a gateway created before the attempt")
+	}
+
+	// ambiguous matches must not be adopted
+	gateways = append(gateways, &vpc.VpnGateway{
+		VpnGatewayId:   helper.String("vpngw-cccccccc"),
+		VpnGatewayName: helper.String("terraform_test"),
+		Zone:           helper.String("ap-guangzhou-3"),
+		CreatedTime:    helper.String(created),
+	})
+	if _, ok := matchCreatedVpnGateway(gateways, "terraform_test", "ap-guangzhou-3", attemptStart); ok {
+		t.Errorf("unexpected match when more than one gateway shares the name")
+	}
+}
+
 func testAccCheckVpnGatewayExists(n string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		logId := getLogId(contextNil)
@@ -243,3 +406,391 @@ resource "tencentcloud_vpn_gateway" "my_ccn_cgw" {
   }
 }
 `
+
+// testAccVpnGatewayCreateDanglingConnection creates a VPN connection on the
+// gateway through the API only, so it is unknown to the state and can only be
+// cleaned up by the gateway's force_delete path.
+func testAccVpnGatewayCreateDanglingConnection(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("vpn gateway %s is not found", n)
+		}
+		cgw, ok := s.RootModule().Resources["tencentcloud_vpn_customer_gateway.force_cgw"]
+		if !ok {
+			return fmt.Errorf("vpn customer gateway is not found")
+		}
+
+		request := vpc.NewCreateVpnConnectionRequest()
+		request.VpnGatewayId = helper.String(rs.Primary.ID)
+		request.CustomerGatewayId = helper.String(cgw.Primary.ID)
+		request.VpnConnectionName = helper.String("tf-dangling-connection")
+		request.PreShareKey = helper.String("test")
+		request.VpcId = helper.String(rs.Primary.Attributes["vpc_id"])
+		request.SecurityPolicyDatabases = []*vpc.SecurityPolicyDatabase{{
+			LocalCidrBlock:  helper.String("172.16.0.0/16"),
+			RemoteCidrBlock: []*string{helper.String("3.3.3.0/32")},
+		}}
+
+		client := testAccProvider.Meta().(*TencentCloudClient).apiV3Conn
+		_, err := client.UseVpcClient().CreateVpnConnection(request)
+		return err
+	}
+}
+
+const testAccForceDeleteVpnGatewayConfig = `
+data "tencentcloud_vpc_instances" "foo" {
+  name = "Default-VPC"
+}
+
+resource "tencentcloud_vpn_customer_gateway" "force_cgw" {
+  name              = "terraform_force_test"
+  public_ip_address = "3.3.3.3"
+}
+
+resource "tencentcloud_vpn_gateway" "my_force_cgw" {
+  name         = "terraform_force_delete_test"
+  vpc_id       = data.tencentcloud_vpc_instances.foo.instance_list.0.vpc_id
+  bandwidth    = 5
+  zone         = "ap-guangzhou-3"
+  force_delete = true
+
+  # destroy the gateway (and its dangling tunnel) before the customer gateway
+  depends_on = [tencentcloud_vpn_customer_gateway.force_cgw]
+}
+`
+
+const testAccCcnAttachedVpnGatewayConfig = `
+resource "tencentcloud_ccn" "ccn" {
+  name        = "tf-ccn-vpngw-attach"
+  description = "ci-temp-test-ccn"
+}
+
+# Create VPNGW of CCN type attached to the CCN instance
+resource "tencentcloud_vpn_gateway" "my_attached_ccn_cgw" {
+  name      = "terraform_ccn_vpngw_attach_test"
+  bandwidth = 5
+  zone      = "ap-guangzhou-3"
+  type      = "CCN"
+  ccn_id    = tencentcloud_ccn.ccn.id
+
+  tags = {
+    test = "tf-ccn-vpngw-attach"
+  }
+}
+`
+
+const testAccPrepaidVpnGatewayConfig = `
+data "tencentcloud_vpc_instances" "foo" {
+  name = "Default-VPC"
+}
+resource "tencentcloud_vpn_gateway" "my_prepaid_cgw" {
+  name           = "terraform_prepaid_test"
+  vpc_id         = data.tencentcloud_vpc_instances.foo.instance_list.0.vpc_id
+  bandwidth      = 5
+  zone           = "ap-guangzhou-3"
+  charge_type    = "PREPAID"
+  prepaid_period = 1
+}
+`
+
+const testAccPrepaidVpnGatewayConfigRenew = `
+data "tencentcloud_vpc_instances" "foo" {
+  name = "Default-VPC"
+}
+resource "tencentcloud_vpn_gateway" "my_prepaid_cgw" {
+  name           = "terraform_prepaid_test"
+  vpc_id         = data.tencentcloud_vpc_instances.foo.instance_list.0.vpc_id
+  bandwidth      = 5
+  zone           = "ap-guangzhou-3"
+  charge_type    = "PREPAID"
+  prepaid_period = 3
+}
+`
+
+const testAccVpnGatewayConfigBogusZone = `
+data "tencentcloud_vpc_instances" "foo" {
+  name = "Default-VPC"
+}
+resource "tencentcloud_vpn_gateway" "my_bogus_cgw" {
+  name      = "terraform_test"
+  vpc_id    = data.tencentcloud_vpc_instances.foo.instance_list.0.vpc_id
+  bandwidth = 5
+  zone      = "ap-guangzhou-99"
+}
+`
+
+func TestBatchDeleteVpnConnections(t *testing.T) {
+	// a mocked gateway with two connections: every connection must be
+	// attempted and all failures must surface in the aggregated error
+	var deleted []string
+	err := batchDeleteVpnConnections("vpngw-mock", []string{"vpnx-aaaaaaaa", "vpnx-bbbbbbbb"}, func(gatewayId, connectionId string) error {
+		if gatewayId != "vpngw-mock" {
+			t.Errorf("unexpected gateway id %s", gatewayId)
+		}
+		deleted = append(deleted, connectionId)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(deleted) != 2 || deleted[0] != "vpnx-aaaaaaaa" || deleted[1] != "vpnx-bbbbbbbb" {
+		t.Fatalf("expected both connections to be deleted in order, got: %v", deleted)
+	}
+
+	deleted = nil
+	err = batchDeleteVpnConnections("vpngw-mock", []string{"vpnx-aaaaaaaa", "vpnx-bbbbbbbb"}, func(gatewayId, connectionId string) error {
+		deleted = append(deleted, connectionId)
+		if connectionId == "vpnx-aaaaaaaa" {
+			return fmt.Errorf("connection %s is stuck", connectionId)
+		}
+		return nil
+	})
+	if err == nil {
+		t.Fatal("expected the stuck connection to be reported")
+	}
+	if !strings.Contains(err.Error(), "vpnx-aaaaaaaa") {
+		t.Fatalf("expected the aggregated error to name the stuck connection, got: %v", err)
+	}
+	if len(deleted) != 2 {
+		t.Fatalf("expected the failure not to stop the remaining deletions, got: %v", deleted)
+	}
+}
+
+func TestVpnGatewayRenameRequest(t *testing.T) {
+	// a rename-only update must not carry a charge type
+	request := vpnGatewayRenameRequest("vpngw-8ccsnclt", "new-name")
+	if request.VpnGatewayName == nil || *request.VpnGatewayName != "new-name" {
+		t.Fatalf("expected the rename request to carry the new name, got: %v", request.VpnGatewayName)
+	}
+	if request.InstanceChargeType != nil {
+		t.Fatalf("expected the rename request not to carry InstanceChargeType, got: %s", *request.InstanceChargeType)
+	}
+
+	chargeRequest, err := vpnGatewayChargeTypeRequest("vpngw-8ccsnclt", VPN_CHARGE_TYPE_PREPAID, VPN_CHARGE_TYPE_POSTPAID_BY_HOUR)
+	if err != nil {
+		t.Fatalf("expected pre-paid to post-paid change to be valid, got: %v", err)
+	}
+	if chargeRequest.InstanceChargeType == nil || *chargeRequest.InstanceChargeType != VPN_CHARGE_TYPE_POSTPAID_BY_HOUR {
+		t.Fatalf("expected the charge type request to carry the new charge type")
+	}
+
+	if _, err := vpnGatewayChargeTypeRequest("vpngw-8ccsnclt", VPN_CHARGE_TYPE_POSTPAID_BY_HOUR, VPN_CHARGE_TYPE_PREPAID); err == nil {
+		t.Fatal("expected post-paid to pre-paid change to be rejected")
+	}
+}
+
+func TestShouldSuppressVpnGatewayVpcIdDiff(t *testing.T) {
+	// a gateway staying in CCN type has no meaningful vpc_id
+	if !shouldSuppressVpnGatewayVpcIdDiff(GATE_WAY_TYPE_CCN, GATE_WAY_TYPE_CCN, "", "vpc-dk8zmwuf") {
+		t.Errorf("expected vpc_id diff to stay suppressed for a gateway remaining in CCN type")
+	}
+	// CCN -> IPSEC needs a VPC, the recreate must not be hidden
+	if shouldSuppressVpnGatewayVpcIdDiff(GATE_WAY_TYPE_CCN, "IPSEC", "", "vpc-dk8zmwuf") {
+		t.Errorf("expected CCN to IPSEC transition with a new VPC to force recreate")
+	}
+	// a plain IPSEC gateway keeping its VPC (e.g. rename-only plan) shows no diff
+	if !shouldSuppressVpnGatewayVpcIdDiff("IPSEC", "IPSEC", "vpc-dk8zmwuf", "vpc-dk8zmwuf") {
+		t.Errorf("expected unchanged vpc_id on an IPSEC gateway to be suppressed")
+	}
+	// a real VPC change on an IPSEC gateway recreates
+	if shouldSuppressVpnGatewayVpcIdDiff("IPSEC", "IPSEC", "vpc-dk8zmwuf", "vpc-00000000") {
+		t.Errorf("expected vpc_id change on an IPSEC gateway to force recreate")
+	}
+}
+
+func TestCountConnectedSslVpnClients(t *testing.T) {
+	clients := []*vpc.SslVpnClient{
+		{State: helper.String(SSL_VPN_CLIENT_STATE_CONNECTED)},
+		{State: helper.String("0")},
+		{State: helper.String(SSL_VPN_CLIENT_STATE_CONNECTED)},
+		{State: nil},
+	}
+
+	if got := countConnectedSslVpnClients(clients); got != 2 {
+		t.Errorf("expected 2 connected clients, got %d", got)
+	}
+	if got := countConnectedSslVpnClients(nil); got != 0 {
+		t.Errorf("expected 0 connected clients for an empty set, got %d", got)
+	}
+}
+
+func TestAccTencentCloudVpnGateway_sslConnectionCount(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVpnGatewayDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSslVpnGatewayConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpnGatewayExists("tencentcloud_vpn_gateway.my_ssl_cgw"),
+					resource.TestCheckResourceAttr("tencentcloud_vpn_gateway.my_ssl_cgw", "type", "SSL"),
+					// a fresh gateway has no connected clients yet
+					resource.TestCheckResourceAttr("tencentcloud_vpn_gateway.my_ssl_cgw", "ssl_active_connection_count", "0"),
+				),
+			},
+		},
+	})
+}
+
+const testAccSslVpnGatewayConfig = `
+resource "tencentcloud_vpn_gateway" "my_ssl_cgw" {
+  name           = "terraform_ssl_test"
+  bandwidth      = 5
+  type           = "SSL"
+  max_connection = 10
+  zone           = "ap-guangzhou-3"
+
+  tags = {
+    test = "tf"
+  }
+}
+`
+
+func TestWaitForVpnGatewayChargeType(t *testing.T) {
+	// the API keeps reporting the old charge type for a while after the switch
+	reported := []string{
+		VPN_CHARGE_TYPE_PREPAID,
+		VPN_CHARGE_TYPE_PREPAID,
+		VPN_CHARGE_TYPE_POSTPAID_BY_HOUR,
+	}
+	calls := 0
+	err := waitForVpnGatewayChargeType(10*time.Second, VPN_CHARGE_TYPE_POSTPAID_BY_HOUR, func() (*vpc.VpnGateway, error) {
+		chargeType := reported[calls]
+		if calls < len(reported)-1 {
+			calls++
+		}
+		return &vpc.VpnGateway{InstanceChargeType: &chargeType}, nil
+	})
+	if err != nil {
+		t.Fatalf("expected the waiter to succeed once the charge type switched, got: %v", err)
+	}
+	if calls != len(reported)-1 {
+		t.Fatalf("expected %d polls before the switch, got %d", len(reported)-1, calls)
+	}
+
+	// a gateway that vanished mid-switch must fail fast
+	err = waitForVpnGatewayChargeType(10*time.Second, VPN_CHARGE_TYPE_POSTPAID_BY_HOUR, func() (*vpc.VpnGateway, error) {
+		return nil, nil
+	})
+	if err == nil {
+		t.Fatal("expected an error for a missing gateway")
+	}
+}
+
+func TestWaitForVpnGatewayBandwidth(t *testing.T) {
+	// the API keeps reporting the old bandwidth for a while after the reset
+	reported := []uint64{5, 5, 10}
+	calls := 0
+	err := waitForVpnGatewayBandwidth(10*time.Second, 10, func() (*vpc.VpnGateway, error) {
+		bandwidth := reported[calls]
+		if calls < len(reported)-1 {
+			calls++
+		}
+		return &vpc.VpnGateway{InternetMaxBandwidthOut: &bandwidth}, nil
+	})
+	if err != nil {
+		t.Fatalf("expected the waiter to succeed once the bandwidth propagated, got: %v", err)
+	}
+	if calls != len(reported)-1 {
+		t.Fatalf("expected %d polls before the new bandwidth, got %d", len(reported)-1, calls)
+	}
+
+	// a gateway that vanished mid-reset must fail fast
+	err = waitForVpnGatewayBandwidth(10*time.Second, 10, func() (*vpc.VpnGateway, error) {
+		return nil, nil
+	})
+	if err == nil {
+		t.Fatal("expected an error for a missing gateway")
+	}
+}
+
+func TestLockVpnGatewayTags(t *testing.T) {
+	const gatewayId = "vpngw-lock-test"
+	var (
+		inCriticalSection int32
+		overlapped        int32
+		wg                sync.WaitGroup
+	)
+
+	// two updates on the same gateway id must serialize
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock := lockVpnGatewayTags(gatewayId)
+			defer unlock()
+			if atomic.AddInt32(&inCriticalSection, 1) > 1 {
+				atomic.StoreInt32(&overlapped, 1)
+			}
+			time.Sleep(50 * time.Millisecond)
+			atomic.AddInt32(&inCriticalSection, -1)
+		}()
+	}
+	wg.Wait()
+
+	if overlapped != 0 {
+		t.Error("expected tag updates on the same gateway id to serialize")
+	}
+
+	// a different gateway id takes an independent lock
+	unlock := lockVpnGatewayTags(gatewayId)
+	otherUnlock := lockVpnGatewayTags("vpngw-lock-other")
+	otherUnlock()
+	unlock()
+}
+
+func TestVpnGatewayBlockedReason(t *testing.T) {
+	blocked := true
+	unblocked := false
+	isolated := VPN_RESTRICT_STATE_ISOLATE
+	normal := VPN_RESTRICT_STATE_NORMAL
+
+	// a blocked, protectively isolated gateway names the isolation
+	reason := vpnGatewayBlockedReason(&vpc.VpnGateway{IsAddressBlocked: &blocked, RestrictState: &isolated})
+	if !strings.Contains(reason, "protectively isolated") {
+		t.Errorf("expected an isolation reason, got %q", reason)
+	}
+
+	// blocked without the isolation state still reports a generic reason
+	if reason := vpnGatewayBlockedReason(&vpc.VpnGateway{IsAddressBlocked: &blocked, RestrictState: &normal}); reason == "" {
+		t.Error("expected a generic reason for a blocked gateway")
+	}
+
+	// an unblocked gateway carries no reason
+	if reason := vpnGatewayBlockedReason(&vpc.VpnGateway{IsAddressBlocked: &unblocked, RestrictState: &normal}); reason != "" {
+		t.Errorf("expected no reason for an unblocked gateway, got %q", reason)
+	}
+	if reason := vpnGatewayBlockedReason(nil); reason != "" {
+		t.Errorf("expected no reason for a missing gateway, got %q", reason)
+	}
+}
+
+func TestCheckVpnGatewayBandwidth(t *testing.T) {
+	// tiers shared by both charge types pass
+	for _, chargeType := range []string{VPN_CHARGE_TYPE_PREPAID, VPN_CHARGE_TYPE_POSTPAID_BY_HOUR} {
+		if err := checkVpnGatewayBandwidth(chargeType, 100); err != nil {
+			t.Errorf("expected bandwidth 100 to be allowed for %s, got: %v", chargeType, err)
+		}
+	}
+
+	// the high postpaid tiers are rejected for a prepaid gateway
+	if err := checkVpnGatewayBandwidth(VPN_CHARGE_TYPE_PREPAID, 1000); err == nil {
+		t.Error("expected bandwidth 1000 to be rejected for a PREPAID gateway")
+	}
+
+	// a value outside every tier is rejected for a postpaid gateway too
+	if err := checkVpnGatewayBandwidth(VPN_CHARGE_TYPE_POSTPAID_BY_HOUR, 2000); err == nil {
+		t.Error("expected bandwidth 2000 to be rejected for a POSTPAID_BY_HOUR gateway")
+	}
+	if err := checkVpnGatewayBandwidth(VPN_CHARGE_TYPE_POSTPAID_BY_HOUR, 1000); err != nil {
+		t.Errorf("expected bandwidth 1000 to be allowed for a POSTPAID_BY_HOUR gateway, got: %v", err)
+	}
+
+	// unknown charge types are left to their own validation
+	if err := checkVpnGatewayBandwidth("SPOTPAID", 42); err != nil {
+		t.Errorf("expected an unknown charge type to pass through, got: %v", err)
+	}
+}
@@ -241,7 +241,7 @@ func (me *SSLService) UploadCertificate(ctx context.Context, request *ssl.Upload
 	response, err = client.UploadCertificate(request)
 	if err != nil {
 		log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%v]",
-			logId, request.GetAction(), request.ToJsonString(), err)
+			logId, request.GetAction(), redactRequestBody(request.ToJsonString()), err)
 		return
 	}
 
@@ -8,6 +8,7 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 
 	cam "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cam/v20190116"
 	sdkErrors "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/errors"
@@ -20,6 +21,40 @@ type CamService struct {
 	client *connectivity.TencentCloudClient
 }
 
+// accountUinCache memoizes the account uin per credential, so resources which
+// need the uin in tag resource names don't look it up on every operation.
+var accountUinCache sync.Map
+
+// DescribeAccountUin returns the uin of the calling account.
+func (me *CamService) DescribeAccountUin(ctx context.Context) (uin string, errRet error) {
+	logId := getLogId(ctx)
+
+	secretId := me.client.Credential.SecretId
+	if v, ok := accountUinCache.Load(secretId); ok {
+		return v.(string), nil
+	}
+
+	request := cam.NewGetUserAppIdRequest()
+	ratelimit.Check(request.GetAction())
+	response, err := me.client.UseCamClient().GetUserAppId(request)
+	if err != nil {
+		log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
+			logId, request.GetAction(), request.ToJsonString(), err.Error())
+		errRet = err
+		return
+	}
+	log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n",
+		logId, request.GetAction(), request.ToJsonString(), response.ToJsonString())
+
+	if response.Response == nil || response.Response.Uin == nil {
+		errRet = fmt.Errorf("get account uin error: empty response")
+		return
+	}
+	uin = *response.Response.Uin
+	accountUinCache.Store(secretId, uin)
+	return
+}
+
 func (me *CamService) DescribeRoleById(ctx context.Context, roleId string) (camInstance *cam.RoleInfo, errRet error) {
 	logId := getLogId(ctx)
 	request := cam.NewDescribeRoleListRequest()
@@ -482,10 +482,21 @@ func (me *CfsService) CfsSnapshotStateRefreshFunc(snapshotId string, failStates
 			return nil, "", err
 		}
 
-		return object, helper.PString(object.Status), nil
+		obj, status := cfsSnapshotRefreshState(object)
+		return obj, status, nil
 	}
 }
 
+// cfsSnapshotRefreshState maps a describe result to a waiter state. A snapshot
+// may not be visible yet right after create because of eventual consistency,
+// so an empty result reports a pending pseudo-state instead of failing the waiter.
+func cfsSnapshotRefreshState(object *cfs.SnapshotInfo) (interface{}, string) {
+	if object == nil {
+		return &cfs.SnapshotInfo{}, CFS_FILE_SYSTEM_STATUS_CREATING
+	}
+	return object, helper.PString(object.Status)
+}
+
 func (me *CfsService) DescribeCfsMountTargetsById(ctx context.Context, fileSystemId string) (mountTargets []*cfs.MountInfo, errRet error) {
 	var (
 		logId   = getLogId(ctx)
@@ -894,6 +894,38 @@ func (me *CvmService) DescribeZones(ctx context.Context) (zones []*cvm.ZoneInfo,
 	return
 }
 
+// availableZonesCache caches the zone names per region, so plan-time zone
+// checks don't call DescribeZones repeatedly in large plans.
+var availableZonesCache sync.Map
+
+// CheckZoneAvailable returns an error if zone is not an available zone of the
+// current region, turning zone typos into plan-time messages.
+func (me *CvmService) CheckZoneAvailable(ctx context.Context, zone string) error {
+	region := me.client.Region
+	var names []string
+	if v, ok := availableZonesCache.Load(region); ok {
+		names = v.([]string)
+	} else {
+		zones, err := me.DescribeZones(ctx)
+		if err != nil {
+			return err
+		}
+		names = make([]string, 0, len(zones))
+		for _, z := range zones {
+			if z.Zone != nil {
+				names = append(names, *z.Zone)
+			}
+		}
+		availableZonesCache.Store(region, names)
+	}
+	for _, name := range names {
+		if name == zone {
+			return nil
+		}
+	}
+	return fmt.Errorf("zone `%s` is not available in region `%s`, available zones: %v", zone, region, names)
+}
+
 func (me *CvmService) CreateReservedInstance(ctx context.Context, configId string, count int64, extendParams map[string]interface{}) (instanceId string, errRet error) {
 	logId := getLogId(ctx)
 	request := cvm.NewPurchaseReservedInstancesOfferingRequest()
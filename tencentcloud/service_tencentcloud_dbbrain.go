@@ -262,3 +262,47 @@ func (me *DbbrainService) DeleteDbbrainSecurityAuditLogExportTaskById(ctx contex
 
 	return
 }
+
+func (me *DbbrainService) DescribeDbbrainDiagEventsByFilter(ctx context.Context, param map[string]interface{}) (diagEvents []*dbbrain.DiagHistoryEventItem, errRet error) {
+	var (
+		logId   = getLogId(ctx)
+		request = dbbrain.NewDescribeDBDiagHistoryRequest()
+	)
+
+	defer func() {
+		if errRet != nil {
+			log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
+				logId, "query objects", request.ToJsonString(), errRet.Error())
+		}
+	}()
+
+	for k, v := range param {
+		if k == "instance_id" {
+			request.InstanceId = v.(*string)
+		}
+
+		if k == "start_time" {
+			request.StartTime = v.(*string)
+		}
+
+		if k == "end_time" {
+			request.EndTime = v.(*string)
+		}
+
+		if k == "product" {
+			request.Product = v.(*string)
+		}
+	}
+
+	ratelimit.Check(request.GetAction())
+	response, err := me.client.UseDbbrainClient().DescribeDBDiagHistory(request)
+	if err != nil {
+		errRet = err
+		return
+	}
+	log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n",
+		logId, request.GetAction(), request.ToJsonString(), response.ToJsonString())
+
+	diagEvents = response.Response.Events
+	return
+}
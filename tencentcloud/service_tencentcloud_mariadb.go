@@ -94,14 +94,25 @@ func (me *MariadbService) DescribeMariadbDbInstancesByFilter(ctx context.Context
 			request.SearchName = v.(*string)
 		}
 
+		if k == "instance_name" {
+			request.SearchName = helper.String("instancename")
+			request.SearchKey = v.(*string)
+		}
+
 		if k == "vpc_id" {
+			request.IsFilterVpc = helper.Bool(true)
 			request.VpcId = v.(*string)
 		}
 
 		if k == "subnet_id" {
+			request.IsFilterVpc = helper.Bool(true)
 			request.SubnetId = v.(*string)
 		}
 
+		if k == "tag_keys" {
+			request.TagKeys = v.([]*string)
+		}
+
 		if k == "excluster_type" {
 			request.IsFilterExcluster = helper.Bool(true)
 			request.ExclusterType = v.(*int64)
@@ -172,6 +183,48 @@ func (me *MariadbService) DescribeMariadbDbInstance(ctx context.Context, instanc
 	return
 }
 
+// DescribeMariadbInstancesByGroup lists the ids of all instances belonging to
+// a dedicated cluster (instance group).
+func (me *MariadbService) DescribeMariadbInstancesByGroup(ctx context.Context, groupId string) (instanceIds []string, errRet error) {
+	var (
+		logId   = getLogId(ctx)
+		request = mariadb.NewDescribeDBInstancesRequest()
+	)
+
+	defer func() {
+		if errRet != nil {
+			log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
+				logId, request.GetAction(), request.ToJsonString(), errRet.Error())
+		}
+	}()
+	request.ExclusterIds = []*string{&groupId}
+
+	var offset, limit int64 = 0, 20
+	for {
+		request.Offset = &offset
+		request.Limit = &limit
+		response, err := me.client.UseMariadbClient().DescribeDBInstances(request)
+		if err != nil {
+			errRet = err
+			return
+		}
+		log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n",
+			logId, request.GetAction(), request.ToJsonString(), response.ToJsonString())
+		if response == nil || len(response.Response.Instances) < 1 {
+			return
+		}
+		for _, instance := range response.Response.Instances {
+			if instance.InstanceId != nil {
+				instanceIds = append(instanceIds, *instance.InstanceId)
+			}
+		}
+		if int64(len(response.Response.Instances)) < limit {
+			return
+		}
+		offset += limit
+	}
+}
+
 func (me *MariadbService) DeleteMariadbDbInstanceById(ctx context.Context, instanceId string) (errRet error) {
 	logId := getLogId(ctx)
 
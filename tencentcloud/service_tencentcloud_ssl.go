@@ -36,7 +36,7 @@ func (me *SslService) CreateCertificate(ctx context.Context, certType, cert, nam
 		response, err := client.UploadCert(createRequest)
 		if err != nil {
 			log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%v]",
-				logId, createRequest.GetAction(), createRequest.ToJsonString(), err)
+				logId, createRequest.GetAction(), redactRequestBody(createRequest.ToJsonString()), err)
 			return retryError(err)
 		}
 
@@ -2,6 +2,10 @@ package tencentcloud
 
 import (
 	"context"
+	"log"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/pkg/errors"
@@ -15,44 +19,170 @@ type TagService struct {
 	client *connectivity.TencentCloudClient
 }
 
-func (me *TagService) ModifyTags(ctx context.Context, resourceName string, replaceTags map[string]string, deleteKeys []string) error {
-	request := tag.NewModifyResourceTagsRequest()
-	request.Resource = &resourceName
-	if len(replaceTags) > 0 {
-		request.ReplaceTags = make([]*tag.Tag, 0, len(replaceTags))
-		for k, v := range replaceTags {
-			key := k
-			value := v
-			replaceTag := &tag.Tag{
-				TagKey:   &key,
-				TagValue: &value,
-			}
-			request.ReplaceTags = append(request.ReplaceTags, replaceTag)
+// a refresh touching many resources of one service repeats the same tag
+// lookups; memoizing them for a short window keeps the tag API out of its
+// rate limit without serving stale data across applies
+const tagReadCacheTTL = 30 * time.Second
+
+// tagReadCache memoizes DescribeResourceTags results per resource for a short
+// window. Any tag modification drops the whole cache, so a fresh apply never
+// reads tags the previous operation changed.
+type tagReadCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]tagReadCacheEntry
+}
+
+type tagReadCacheEntry struct {
+	tags    map[string]string
+	fetched time.Time
+}
+
+var resourceTagsCache = &tagReadCache{ttl: tagReadCacheTTL}
+
+// fetch returns the cached tags of key when they are still fresh, otherwise
+// it calls describe once and caches the result. Returned maps are copies, so
+// callers can modify them without poisoning the cache.
+func (c *tagReadCache) fetch(key string, now time.Time, describe func() (map[string]string, error)) (map[string]string, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && now.Sub(entry.fetched) < c.ttl {
+		return copyTags(entry.tags), nil
+	}
+
+	tags, err := describe()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	if c.entries == nil {
+		c.entries = make(map[string]tagReadCacheEntry)
+	}
+	c.entries[key] = tagReadCacheEntry{tags: copyTags(tags), fetched: now}
+	c.mu.Unlock()
+	return tags, nil
+}
+
+// reset drops every cached entry; called after each tag modification
+func (c *tagReadCache) reset() {
+	c.mu.Lock()
+	c.entries = nil
+	c.mu.Unlock()
+}
+
+// store caches the tags for key as of now without a describe round trip, so
+// batched reads can warm the per-resource entries
+func (c *tagReadCache) store(key string, now time.Time, tags map[string]string) {
+	c.mu.Lock()
+	if c.entries == nil {
+		c.entries = make(map[string]tagReadCacheEntry)
+	}
+	c.entries[key] = tagReadCacheEntry{tags: copyTags(tags), fetched: now}
+	c.mu.Unlock()
+}
+
+func copyTags(tags map[string]string) map[string]string {
+	if tags == nil {
+		return nil
+	}
+	copied := make(map[string]string, len(tags))
+	for k, v := range tags {
+		copied[k] = v
+	}
+	return copied
+}
+
+type modifyTagsBatch struct {
+	replaceTags []*tag.Tag
+	deleteTags  []*tag.TagKeyObject
+}
+
+// splitModifyTagsBatches splits the requested tag operations into batches of
+// at most batchSize operations each, so resources with many tags stay within
+// the per-call limit of ModifyResourceTags
+func splitModifyTagsBatches(replaceTags map[string]string, deleteKeys []string, batchSize int) []modifyTagsBatch {
+	batches := make([]modifyTagsBatch, 0)
+	current := modifyTagsBatch{}
+	count := 0
+
+	flush := func() {
+		if count > 0 {
+			batches = append(batches, current)
+			current = modifyTagsBatch{}
+			count = 0
 		}
 	}
-	if len(deleteKeys) > 0 {
-		request.DeleteTags = make([]*tag.TagKeyObject, 0, len(deleteKeys))
-		for _, v := range deleteKeys {
-			key := v
-			deleteKey := &tag.TagKeyObject{
-				TagKey: &key,
-			}
-			request.DeleteTags = append(request.DeleteTags, deleteKey)
+
+	for k, v := range replaceTags {
+		key := k
+		value := v
+		current.replaceTags = append(current.replaceTags, &tag.Tag{
+			TagKey:   &key,
+			TagValue: &value,
+		})
+		count++
+		if count == batchSize {
+			flush()
+		}
+	}
+	for _, v := range deleteKeys {
+		key := v
+		current.deleteTags = append(current.deleteTags, &tag.TagKeyObject{
+			TagKey: &key,
+		})
+		count++
+		if count == batchSize {
+			flush()
 		}
 	}
+	flush()
 
-	return resource.Retry(writeRetryTimeout, func() *resource.RetryError {
-		ratelimit.Check(request.GetAction())
+	return batches
+}
+
+func (me *TagService) ModifyTags(ctx context.Context, resourceName string, replaceTags map[string]string, deleteKeys []string) error {
+	logId := getLogId(ctx)
+	log.Printf("[INFO]%s modify tags on %s: replacing %d tags, deleting %d",
+		logId, resourceName, len(replaceTags), len(deleteKeys))
 
-		if _, err := me.client.UseTagClient().ModifyResourceTags(request); err != nil {
-			return retryError(errors.WithStack(err))
+	for _, batch := range splitModifyTagsBatches(replaceTags, deleteKeys, MODIFY_TAGS_LIMIT) {
+		request := tag.NewModifyResourceTagsRequest()
+		request.Resource = &resourceName
+		if len(batch.replaceTags) > 0 {
+			request.ReplaceTags = batch.replaceTags
+		}
+		if len(batch.deleteTags) > 0 {
+			request.DeleteTags = batch.deleteTags
 		}
 
-		return nil
+		if err := resource.Retry(writeRetryTimeout, func() *resource.RetryError {
+			ratelimit.Check(request.GetAction())
+
+			if _, err := me.client.UseTagClient().ModifyResourceTags(request); err != nil {
+				return retryError(errors.WithStack(err))
+			}
+
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	resourceTagsCache.reset()
+
+	return nil
+}
+
+func (me *TagService) DescribeResourceTags(ctx context.Context, serviceType, resourceType, region, resourceId string) (map[string]string, error) {
+	cacheKey := strings.Join([]string{serviceType, resourceType, region, resourceId}, FILED_SP)
+	return resourceTagsCache.fetch(cacheKey, time.Now(), func() (map[string]string, error) {
+		return me.describeResourceTags(serviceType, resourceType, region, resourceId)
 	})
 }
 
-func (me *TagService) DescribeResourceTags(ctx context.Context, serviceType, resourceType, region, resourceId string) (tags map[string]string, err error) {
+func (me *TagService) describeResourceTags(serviceType, resourceType, region, resourceId string) (tags map[string]string, err error) {
 	request := tag.NewDescribeResourceTagsByResourceIdsRequest()
 	request.ServiceType = &serviceType
 	request.ResourcePrefix = &resourceType
@@ -79,16 +209,7 @@ func (me *TagService) DescribeResourceTags(ctx context.Context, serviceType, res
 			allTags := response.Response.Tags
 			count = len(allTags)
 
-			for _, t := range allTags {
-				if *t.ResourceId != resourceId {
-					continue
-				}
-				if tags == nil {
-					tags = make(map[string]string)
-				}
-
-				tags[*t.TagKey] = *t.TagValue
-			}
+			tags = mergeResourceTagsPage(tags, allTags, resourceId)
 
 			return nil
 		}); err != nil {
@@ -101,6 +222,141 @@ func (me *TagService) DescribeResourceTags(ctx context.Context, serviceType, res
 	return
 }
 
+// DescribeResourceTagsByResourceIds reads the tags of several same-typed
+// resources at once, batching DESCRIBE_TAGS_LIMIT resource ids per request so
+// list data sources do not issue one tag call per resource. The result maps
+// resource id to its tags; untagged resources have no entry. Each fetched
+// resource also warms the per-resource cache used by DescribeResourceTags.
+func (me *TagService) DescribeResourceTagsByResourceIds(ctx context.Context, serviceType, resourceType, region string, resourceIds []string) (map[string]map[string]string, error) {
+	byResource := make(map[string]map[string]string, len(resourceIds))
+	now := time.Now()
+
+	for start := 0; start < len(resourceIds); start += DESCRIBE_TAGS_LIMIT {
+		end := start + DESCRIBE_TAGS_LIMIT
+		if end > len(resourceIds) {
+			end = len(resourceIds)
+		}
+
+		request := tag.NewDescribeResourceTagsByResourceIdsRequest()
+		request.ServiceType = &serviceType
+		request.ResourcePrefix = &resourceType
+		request.ResourceRegion = &region
+		request.ResourceIds = helper.Strings(resourceIds[start:end])
+		request.Limit = helper.IntUint64(DESCRIBE_TAGS_LIMIT)
+
+		var offset uint64
+		request.Offset = &offset
+
+		// for run loop at least once
+		count := DESCRIBE_TAGS_LIMIT
+		for count == DESCRIBE_TAGS_LIMIT {
+			if err := resource.Retry(readRetryTimeout, func() *resource.RetryError {
+				ratelimit.Check(request.GetAction())
+
+				response, err := me.client.UseTagClient().DescribeResourceTagsByResourceIds(request)
+				if err != nil {
+					count = 0
+
+					return retryError(errors.WithStack(err))
+				}
+
+				allTags := response.Response.Tags
+				count = len(allTags)
+
+				mergeResourceTagsPages(byResource, allTags)
+
+				return nil
+			}); err != nil {
+				return nil, err
+			}
+
+			offset += uint64(count)
+		}
+	}
+
+	for _, resourceId := range resourceIds {
+		cacheKey := strings.Join([]string{serviceType, resourceType, region, resourceId}, FILED_SP)
+		resourceTagsCache.store(cacheKey, now, byResource[resourceId])
+	}
+
+	return byResource, nil
+}
+
+// normalizeResourceTagsCase rewrites tags returned by the API that differ
+// from the configured ones only by key or value casing back to the configured
+// form, so services folding tag case do not produce perpetual diffs. Tags
+// without a case-insensitive match are kept as returned.
+func normalizeResourceTagsCase(apiTags, configured map[string]string) map[string]string {
+	if len(apiTags) == 0 || len(configured) == 0 {
+		return apiTags
+	}
+
+	configuredKeys := make(map[string]string, len(configured))
+	for k := range configured {
+		configuredKeys[strings.ToLower(k)] = k
+	}
+
+	normalized := make(map[string]string, len(apiTags))
+	for k, v := range apiTags {
+		configuredKey, ok := configuredKeys[strings.ToLower(k)]
+		if !ok {
+			normalized[k] = v
+			continue
+		}
+		if configuredValue := configured[configuredKey]; strings.EqualFold(v, configuredValue) {
+			v = configuredValue
+		}
+		normalized[configuredKey] = v
+	}
+	return normalized
+}
+
+// missingResourceTags returns the configured tags that are absent from, or
+// carry a different value in, the tags currently on the resource. Callers use
+// it to re-apply tags an API operation dropped as a side effect.
+func missingResourceTags(configured, actual map[string]string) map[string]string {
+	missing := make(map[string]string)
+	for k, v := range configured {
+		if actualValue, ok := actual[k]; !ok || actualValue != v {
+			missing[k] = v
+		}
+	}
+	return missing
+}
+
+// mergeResourceTagsPage folds one page of DescribeResourceTagsByResourceIds
+// results for the given resource into tags, so resources carrying more than
+// DESCRIBE_TAGS_LIMIT tags accumulate across pages instead of keeping only the
+// last one.
+func mergeResourceTagsPage(tags map[string]string, page []*tag.TagResource, resourceId string) map[string]string {
+	for _, t := range page {
+		if *t.ResourceId != resourceId {
+			continue
+		}
+		if tags == nil {
+			tags = make(map[string]string)
+		}
+
+		tags[*t.TagKey] = *t.TagValue
+	}
+	return tags
+}
+
+// mergeResourceTagsPages folds one page of DescribeResourceTagsByResourceIds
+// results into the per-resource tag maps of a batched read, accumulating
+// across pages the same way mergeResourceTagsPage does for a single resource.
+func mergeResourceTagsPages(byResource map[string]map[string]string, page []*tag.TagResource) {
+	for _, t := range page {
+		tags := byResource[*t.ResourceId]
+		if tags == nil {
+			tags = make(map[string]string)
+			byResource[*t.ResourceId] = tags
+		}
+
+		tags[*t.TagKey] = *t.TagValue
+	}
+}
+
 func diffTags(oldTags, newTags map[string]interface{}) (replaceTags map[string]string, deleteTags []string) {
 	replaceTags = make(map[string]string)
 	deleteTags = make([]string, 0)
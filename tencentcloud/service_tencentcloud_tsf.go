@@ -57,6 +57,44 @@ func (me *TsfService) DescribeTsfApplicationConfigById(ctx context.Context, conf
 	return
 }
 
+func (me *TsfService) DescribeTsfApplicationConfigVersions(ctx context.Context, applicationId, configName string) (configs []*tsf.Config, errRet error) {
+	logId := getLogId(ctx)
+
+	request := tsf.NewDescribeConfigsRequest()
+	request.ApplicationId = &applicationId
+	request.ConfigName = &configName
+
+	defer func() {
+		if errRet != nil {
+			log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n", logId, request.GetAction(), request.ToJsonString(), errRet.Error())
+		}
+	}()
+
+	var offset int64 = 0
+	var limit int64 = 20
+	for {
+		request.Offset = &offset
+		request.Limit = &limit
+		ratelimit.Check(request.GetAction())
+
+		response, err := me.client.UseTsfClient().DescribeConfigs(request)
+		if err != nil {
+			errRet = err
+			return
+		}
+		log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n", logId, request.GetAction(), request.ToJsonString(), response.ToJsonString())
+
+		if response.Response.Result == nil || len(response.Response.Result.Content) < 1 {
+			return
+		}
+		configs = append(configs, response.Response.Result.Content...)
+		if len(response.Response.Result.Content) < int(limit) {
+			return
+		}
+		offset += limit
+	}
+}
+
 func (me *TsfService) DeleteTsfApplicationConfigById(ctx context.Context, configId string) (errRet error) {
 	logId := getLogId(ctx)
 
@@ -143,3 +181,61 @@ func (me *TsfService) DeleteTsfMicroserviceById(ctx context.Context, microservic
 
 	return
 }
+
+func (me *TsfService) DescribeTsfApplicationConfigReleaseById(ctx context.Context, configId, groupId string) (configRelease *tsf.ConfigRelease, errRet error) {
+	logId := getLogId(ctx)
+
+	request := tsf.NewDescribeConfigReleasesRequest()
+	request.ConfigId = &configId
+	request.GroupId = &groupId
+
+	defer func() {
+		if errRet != nil {
+			log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n", logId, request.GetAction(), request.ToJsonString(), errRet.Error())
+		}
+	}()
+
+	ratelimit.Check(request.GetAction())
+
+	response, err := me.client.UseTsfClient().DescribeConfigReleases(request)
+	if err != nil {
+		errRet = err
+		return
+	}
+	log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n", logId, request.GetAction(), request.ToJsonString(), response.ToJsonString())
+
+	if response.Response.Result == nil {
+		return
+	}
+
+	if len(response.Response.Result.Content) < 1 {
+		return
+	}
+
+	configRelease = response.Response.Result.Content[0]
+	return
+}
+
+func (me *TsfService) RevokeTsfApplicationConfigRelease(ctx context.Context, configReleaseId string) (errRet error) {
+	logId := getLogId(ctx)
+
+	request := tsf.NewRevocationConfigRequest()
+	request.ConfigReleaseId = &configReleaseId
+
+	defer func() {
+		if errRet != nil {
+			log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n", logId, request.GetAction(), request.ToJsonString(), errRet.Error())
+		}
+	}()
+
+	ratelimit.Check(request.GetAction())
+
+	response, err := me.client.UseTsfClient().RevocationConfig(request)
+	if err != nil {
+		errRet = err
+		return
+	}
+	log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n", logId, request.GetAction(), request.ToJsonString(), response.ToJsonString())
+
+	return
+}
@@ -14,6 +14,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
 	sdkErrors "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/errors"
@@ -2257,6 +2258,31 @@ func (me *VpcService) DescribeEipById(ctx context.Context, eipId string) (eip *v
 	return
 }
 
+func (me *VpcService) EipStateRefreshFunc(eipId string, failStates []string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		ctx := contextNil
+
+		object, err := me.DescribeEipById(ctx, eipId)
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		obj, status := eipRefreshState(object)
+		return obj, status, nil
+	}
+}
+
+// eipRefreshState maps a describe result to a waiter state. A freshly
+// allocated EIP may not be visible yet because of eventual consistency, so an
+// empty result reports the creating pseudo-state instead of failing the waiter.
+func eipRefreshState(eip *vpc.Address) (interface{}, string) {
+	if eip == nil || eip.AddressStatus == nil {
+		return &vpc.Address{}, EIP_STATUS_CREATING
+	}
+	return eip, *eip.AddressStatus
+}
+
 func (me *VpcService) DescribeEipByFilter(ctx context.Context, filters map[string][]string) (eips []*vpc.Address, errRet error) {
 	logId := getLogId(ctx)
 	request := vpc.NewDescribeAddressesRequest()
@@ -2512,6 +2538,43 @@ func (me *VpcService) DisassociateNatGatewayAddress(ctx context.Context, request
 	return
 }
 
+// isEipAlreadyUnattachedError reports whether a DisassociateAddress failure
+// just means the EIP is no longer bound, which callers can treat as success.
+func isEipAlreadyUnattachedError(err error) bool {
+	sdkErr, ok := err.(*sdkErrors.TencentCloudSDKError)
+	if !ok {
+		return false
+	}
+	return sdkErr.Code == EIP_UNATTACH_NOT_PERMIT || sdkErr.Code == EIP_UNATTACH_STATUS_NOT_PERMIT
+}
+
+// DescribeEipAssociations lists the bindings an EIP still backs, as human
+// readable descriptors. An unbound or missing EIP yields none.
+func (me *VpcService) DescribeEipAssociations(ctx context.Context, eipId string) ([]string, error) {
+	eip, err := me.DescribeEipById(ctx, eipId)
+	if err != nil {
+		return nil, err
+	}
+	return eipAssociations(eip), nil
+}
+
+// eipAssociations maps an address to its remaining binding descriptors; an
+// EIP can back several bindings at once, e.g. a NAT gateway plus a dangling
+// elastic network interface.
+func eipAssociations(eip *vpc.Address) []string {
+	if eip == nil || (eip.AddressStatus != nil && *eip.AddressStatus == EIP_STATUS_UNBIND) {
+		return nil
+	}
+	var bindings []string
+	if eip.InstanceId != nil && *eip.InstanceId != "" {
+		bindings = append(bindings, fmt.Sprintf("instance %s", *eip.InstanceId))
+	}
+	if eip.NetworkInterfaceId != nil && *eip.NetworkInterfaceId != "" {
+		bindings = append(bindings, fmt.Sprintf("network interface %s", *eip.NetworkInterfaceId))
+	}
+	return bindings
+}
+
 func (me *VpcService) UnattachEip(ctx context.Context, eipId string) error {
 	eipUnattachLocker.Lock()
 	defer eipUnattachLocker.Unlock()
@@ -2556,6 +2619,12 @@ func (me *VpcService) UnattachEip(ctx context.Context, eipId string) error {
 	ratelimit.Check(request.GetAction())
 	response, err := me.client.UseVpcClient().DisassociateAddress(request)
 	if err != nil {
+		// another actor (e.g. tencentcloud_eip_association) may have detached
+		// the EIP between the describe above and this call
+		if isEipAlreadyUnattachedError(err) {
+			log.Printf("[WARN]%s eip %s already unattached: %v", logId, eipId, err)
+			return nil
+		}
 		log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
 			logId, request.GetAction(), request.ToJsonString(), err.Error())
 		return err
@@ -4393,7 +4462,31 @@ func (me *VpcService) DeleteAddressTemplateGroup(ctx context.Context, templateGr
 	return err
 }
 
-func (me *VpcService) CreateServiceTemplate(ctx context.Context, name string, services []interface{}) (templateId string, errRet error) {
+// buildServicesInfo attaches the given description to each service entry; the
+// API stores remarks per service, not per template
+func buildServicesInfo(services []interface{}, description string) []*vpc.ServicesInfo {
+	servicesExtra := make([]*vpc.ServicesInfo, 0, len(services))
+	for _, v := range services {
+		servicesExtra = append(servicesExtra, &vpc.ServicesInfo{
+			Service:     helper.String(v.(string)),
+			Description: helper.String(description),
+		})
+	}
+	return servicesExtra
+}
+
+// serviceTemplateDescription derives the template description from the
+// per-service remarks, the first non-empty one wins
+func serviceTemplateDescription(servicesExtra []*vpc.ServicesInfo) string {
+	for _, info := range servicesExtra {
+		if info != nil && info.Description != nil && *info.Description != "" {
+			return *info.Description
+		}
+	}
+	return ""
+}
+
+func (me *VpcService) CreateServiceTemplate(ctx context.Context, name string, services []interface{}, description string) (templateId string, errRet error) {
 	logId := getLogId(ctx)
 	request := vpc.NewCreateServiceTemplateRequest()
 	defer func() {
@@ -4404,9 +4497,13 @@ func (me *VpcService) CreateServiceTemplate(ctx context.Context, name string, se
 	}()
 
 	request.ServiceTemplateName = &name
-	request.Services = make([]*string, len(services))
-	for i, v := range services {
-		request.Services[i] = helper.String(v.(string))
+	if description != "" {
+		request.ServicesExtra = buildServicesInfo(services, description)
+	} else {
+		request.Services = make([]*string, len(services))
+		for i, v := range services {
+			request.Services[i] = helper.String(v.(string))
+		}
 	}
 
 	ratelimit.Check(request.GetAction())
@@ -4424,7 +4521,7 @@ func (me *VpcService) CreateServiceTemplate(ctx context.Context, name string, se
 	return
 }
 
-func (me *VpcService) ModifyServiceTemplate(ctx context.Context, templateId string, name string, services []interface{}) (errRet error) {
+func (me *VpcService) ModifyServiceTemplate(ctx context.Context, templateId string, name string, services []interface{}, description string) (errRet error) {
 	logId := getLogId(ctx)
 	request := vpc.NewModifyServiceTemplateAttributeRequest()
 	defer func() {
@@ -4436,9 +4533,13 @@ func (me *VpcService) ModifyServiceTemplate(ctx context.Context, templateId stri
 
 	request.ServiceTemplateId = &templateId
 	request.ServiceTemplateName = &name
-	request.Services = make([]*string, len(services))
-	for i, v := range services {
-		request.Services[i] = helper.String(v.(string))
+	if description != "" {
+		request.ServicesExtra = buildServicesInfo(services, description)
+	} else {
+		request.Services = make([]*string, len(services))
+		for i, v := range services {
+			request.Services[i] = helper.String(v.(string))
+		}
 	}
 
 	ratelimit.Check(request.GetAction())
@@ -4475,27 +4576,38 @@ func (me *VpcService) DescribeServiceTemplates(ctx context.Context, filter []*vp
 		}
 	}()
 
-	var offset, limit = 0, 100
 	request.Filters = filter
 
-	for {
+	return collectServiceTemplatePages(100, func(offset, limit int) ([]*vpc.ServiceTemplate, error) {
 		request.Offset = helper.String(strconv.Itoa(offset))
 		request.Limit = helper.String(strconv.Itoa(limit))
 
 		ratelimit.Check(request.GetAction())
 		response, err := me.client.UseVpcClient().DescribeServiceTemplates(request)
 		if err != nil {
-			errRet = err
-			return
+			return nil, err
 		}
 		if response == nil || response.Response == nil {
-			errRet = fmt.Errorf("TencentCloud SDK return nil response, %s", request.GetAction())
+			return nil, fmt.Errorf("TencentCloud SDK return nil response, %s", request.GetAction())
 		}
-		templateList = append(templateList, response.Response.ServiceTemplateSet...)
-		if len(response.Response.ServiceTemplateSet) < limit {
+		return response.Response.ServiceTemplateSet, nil
+	})
+}
+
+// collectServiceTemplatePages walks fetch page by page until a short page
+// marks the end, so accounts with more templates than one page holds still
+// get the full set.
+func collectServiceTemplatePages(limit int, fetch func(offset, limit int) ([]*vpc.ServiceTemplate, error)) (templateList []*vpc.ServiceTemplate, errRet error) {
+	for offset := 0; ; offset += limit {
+		page, err := fetch(offset, limit)
+		if err != nil {
+			errRet = err
+			return
+		}
+		templateList = append(templateList, page...)
+		if len(page) < limit {
 			return
 		}
-		offset += limit
 	}
 }
 
@@ -5767,3 +5879,82 @@ func (me *VpcService) DescribeVpcBandwidthPackageByEip(ctx context.Context, eipI
 
 	return
 }
+
+// batchDeleteVpnConnections deletes every listed connection through deleteFn.
+// A failed tunnel does not stop the remaining deletions; all failures are
+// returned aggregated so the caller sees each stuck connection at once.
+func batchDeleteVpnConnections(gatewayId string, connectionIds []string, deleteFn func(gatewayId, connectionId string) error) error {
+	var errs *multierror.Error
+	for _, connectionId := range connectionIds {
+		if err := deleteFn(gatewayId, connectionId); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+// DeleteVpnConnectionsByGateway removes all VPN connections associated with a
+// gateway and waits for each deletion to be accepted. It is used by the
+// gateway `force_delete` teardown but can serve any caller that needs a clean
+// gateway.
+func (me *VpcService) DeleteVpnConnectionsByGateway(ctx context.Context, gatewayId string) (errRet error) {
+	logId := getLogId(ctx)
+
+	request := vpc.NewDescribeVpnConnectionsRequest()
+	request.Filters = []*vpc.Filter{
+		{
+			Name:   helper.String("vpn-gateway-id"),
+			Values: []*string{&gatewayId},
+		},
+	}
+
+	var connectionIds []string
+	offset := uint64(0)
+	limit := uint64(VPN_DESCRIBE_LIMIT)
+	request.Offset = &offset
+	request.Limit = &limit
+	for {
+		var result *vpc.DescribeVpnConnectionsResponse
+		if errRet = resource.Retry(readRetryTimeout, func() *resource.RetryError {
+			ratelimit.Check(request.GetAction())
+			response, e := me.client.UseVpcClient().DescribeVpnConnections(request)
+			if e != nil {
+				log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
+					logId, request.GetAction(), request.ToJsonString(), e.Error())
+				return retryError(e)
+			}
+			result = response
+			return nil
+		}); errRet != nil {
+			return
+		}
+		for _, connection := range result.Response.VpnConnectionSet {
+			if connection.VpnConnectionId != nil {
+				connectionIds = append(connectionIds, *connection.VpnConnectionId)
+			}
+		}
+		if len(result.Response.VpnConnectionSet) < int(limit) {
+			break
+		}
+		offset += limit
+	}
+
+	errRet = batchDeleteVpnConnections(gatewayId, connectionIds, func(gatewayId, connectionId string) error {
+		deleteRequest := vpc.NewDeleteVpnConnectionRequest()
+		deleteRequest.VpnGatewayId = &gatewayId
+		deleteRequest.VpnConnectionId = &connectionId
+		return resource.Retry(writeRetryTimeout, func() *resource.RetryError {
+			ratelimit.Check(deleteRequest.GetAction())
+			if _, e := me.client.UseVpcClient().DeleteVpnConnection(deleteRequest); e != nil {
+				if ee, ok := e.(*sdkErrors.TencentCloudSDKError); ok && ee.GetCode() == "UnsupportedOperation.InvalidState" {
+					return resource.RetryableError(fmt.Errorf("VPN connection %s state is not ready, wait to be `AVAILABLE`.", connectionId))
+				}
+				log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
+					logId, deleteRequest.GetAction(), deleteRequest.ToJsonString(), e.Error())
+				return retryError(e)
+			}
+			return nil
+		})
+	})
+	return
+}
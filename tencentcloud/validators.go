@@ -129,6 +129,20 @@ func validateKeyPairName(v interface{}, k string) (ws []string, errors []error)
 	return
 }
 
+func validateCfsSnapshotName(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	length := utf8.RuneCountInString(value)
+	if length < 1 || length > 64 {
+		errors = append(errors, fmt.Errorf("the length of %s must be 1-64: %s", k, value))
+	}
+
+	pattern := `^[\p{Han}a-zA-Z0-9_.()\[\]-]+$`
+	if match, _ := regexp.MatchString(pattern, value); !match {
+		errors = append(errors, fmt.Errorf("%s only support chinese characters, letters, numbers and \"-_.()[]\": %s", k, value))
+	}
+	return
+}
+
 func validateAllowedStringValueIgnoreCase(ss []string) schema.SchemaValidateFunc {
 	var upperStrs = make([]string, len(ss))
 	for index, value := range ss {